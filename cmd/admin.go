@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "🛡️ Administration commands (admin role only)",
+}
+
+var adminUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage users in the admin tab",
+}
+
+var adminUsersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List users with their role, manager and status",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := adminClient()
+		users, err := loadAdminUsers(client)
+		if err != nil {
+			log.Fatalf("  Failed to read users: %v", err)
+		}
+		fmt.Printf("%-10s %-9s %-10s %s\n", "emp_id", "role", "manager", "status")
+		for _, u := range users {
+			fmt.Printf("%-10s %-9s %-10s %s\n", u.EmpID, u.Role, u.Manager, u.Status)
+		}
+	},
+}
+
+var adminUsersAddCmd = &cobra.Command{
+	Use:   "add <emp_id>",
+	Short: "Add a user and create their sheet",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := adminClient()
+		switch adminRole {
+		case roleEmployee, roleManager, roleAdmin:
+		default:
+			log.Fatalf("  Invalid --role '%s'. Use employee, manager or admin.", adminRole)
+		}
+
+		password := adminPassword
+		if password == "" {
+			password = promptPassword(args[0])
+		}
+		if err := createUserInAdmin(client, args[0], password, adminRole, adminManager); err != nil {
+			log.Fatalf("  Failed to create user: %v", err)
+		}
+		fmt.Printf("👤 Added %s as %s\n", args[0], adminRole)
+	},
+}
+
+var adminUsersDisableCmd = &cobra.Command{
+	Use:   "disable <emp_id>",
+	Short: "Block a user from logging in, keeping their history",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := adminClient()
+		user := findAdminUser(client, args[0])
+		if user.EmpID == internal.CurrentUserID {
+			log.Fatalf("  You cannot disable yourself.")
+		}
+		if err := client.Update(fmt.Sprintf("admin!E%d", user.Row), [][]interface{}{{userDisabled}}, "RAW"); err != nil {
+			log.Fatalf("  Failed to disable user: %v", err)
+		}
+		fmt.Printf("🚫 Disabled %s\n", user.EmpID)
+	},
+}
+
+var adminUsersEnableCmd = &cobra.Command{
+	Use:   "enable <emp_id>",
+	Short: "Allow a disabled user to log in again",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := adminClient()
+		user := findAdminUser(client, args[0])
+		if user.Status == userDeleted {
+			log.Fatalf("  %s is deleted; their sheet is archived.", user.EmpID)
+		}
+		if err := client.Update(fmt.Sprintf("admin!E%d", user.Row), [][]interface{}{{userActive}}, "RAW"); err != nil {
+			log.Fatalf("  Failed to enable user: %v", err)
+		}
+		fmt.Printf("   Enabled %s\n", user.EmpID)
+	},
+}
+
+var adminUsersResetCmd = &cobra.Command{
+	Use:   "reset-password <emp_id>",
+	Short: "Set a new password for a user",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := adminClient()
+		user := findAdminUser(client, args[0])
+		password := adminPassword
+		if password == "" {
+			password = promptPassword(user.EmpID)
+		}
+		if err := client.Update(fmt.Sprintf("admin!B%d", user.Row), [][]interface{}{{password}}, "RAW"); err != nil {
+			log.Fatalf("  Failed to reset password: %v", err)
+		}
+		fmt.Printf("🔑 Password of %s reset\n", user.EmpID)
+	},
+}
+
+var adminUsersDeleteCmd = &cobra.Command{
+	Use:   "delete <emp_id>",
+	Short: "Delete a user, archiving and hiding their sheet",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := adminClient()
+		user := findAdminUser(client, args[0])
+		if user.EmpID == internal.CurrentUserID {
+			log.Fatalf("  You cannot delete yourself.")
+		}
+		if user.Status == userDeleted {
+			fmt.Printf("ℹ️ %s is already deleted.\n", user.EmpID)
+			return
+		}
+
+		fmt.Printf("❓ Delete %s and archive their sheet? (yes/no): ", user.EmpID)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "yes" && answer != "y" {
+			fmt.Println("🚫 Aborted.")
+			return
+		}
+
+		if err := archiveUserSheet(client, user.EmpID); err != nil {
+			log.Fatalf("  Failed to archive sheet: %v", err)
+		}
+		if err := client.Update(fmt.Sprintf("admin!E%d", user.Row), [][]interface{}{{userDeleted}}, "RAW"); err != nil {
+			log.Fatalf("  Failed to delete user: %v", err)
+		}
+		fmt.Printf("🗄️  Deleted %s; their sheet is kept as '%s'\n", user.EmpID, archivePrefix+user.EmpID)
+	},
+}
+
+// adminClient checks login and the admin role and returns the client.
+func adminClient() store.Client {
+	if !internal.IsLoggedIn() {
+		fmt.Println("  Please run 'timesheet setup' first.")
+		os.Exit(1)
+	}
+	client := getSheetsClient()
+	requireRole(client, internal.CurrentUserID, roleAdmin)
+	return client
+}
+
+func findAdminUser(client store.Client, empID string) adminUser {
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		log.Fatalf("  Failed to read users: %v", err)
+	}
+	for _, u := range users {
+		if u.EmpID == empID {
+			return u
+		}
+	}
+	log.Fatalf("  No user '%s' in the admin tab.", empID)
+	return adminUser{}
+}
+
+func promptPassword(empID string) string {
+	fmt.Printf("🔑 Password for %s: ", empID)
+	password, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	password = strings.TrimSpace(password)
+	if password == "" {
+		log.Fatalf("  Password cannot be empty.")
+	}
+	return password
+}
+
+// archiveUserSheet renames the user's sheet with archivePrefix and hides it.
+func archiveUserSheet(client store.Client, empID string) error {
+	ss, err := client.Spreadsheet()
+	if err != nil {
+		return err
+	}
+	for _, sheet := range ss.Sheets {
+		if sheet.Properties.Title != empID {
+			continue
+		}
+		_, err = client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{
+				{
+					UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+						Properties: &sheets.SheetProperties{
+							SheetId: sheet.Properties.SheetId,
+							Title:   archivePrefix + empID,
+							Hidden:  true,
+						},
+						Fields: "title,hidden",
+					},
+				},
+			},
+		})
+		return err
+	}
+	return nil
+}
+
+// The admin tab holds one row per user:
+//
+//	admin: emp_id | password | role | manager | status
+//
+// An empty role means roleEmployee and an empty status userActive. manager
+// is the EMP ID the user reports to, who reviews their submitted weeks.
+const (
+	roleEmployee = "employee"
+	roleManager  = "manager"
+	roleAdmin    = "admin"
+
+	userActive   = "active"
+	userDisabled = "disabled"
+	userDeleted  = "deleted"
+
+	// archivePrefix is put before the sheet name of deleted users.
+	archivePrefix = "archived-"
+)
+
+var (
+	adminRole     string
+	adminManager  string
+	adminPassword string
+)
+
+type adminUser struct {
+	Row      int
+	EmpID    string
+	Password string
+	Role     string
+	Manager  string
+	Status   string
+}
+
+func loadAdminUsers(client store.Client) ([]adminUser, error) {
+	resp, err := client.Get("admin!A2:E")
+	if err != nil {
+		return nil, err
+	}
+
+	var users []adminUser
+	for i, row := range resp {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		role := strings.ToLower(cellString(row, 2))
+		if role == "" {
+			role = roleEmployee
+		}
+		status := strings.ToLower(cellString(row, 4))
+		if status == "" {
+			status = userActive
+		}
+		users = append(users, adminUser{Row: i + 2, EmpID: id, Password: cellString(row, 1), Role: role, Manager: cellString(row, 3), Status: status})
+	}
+	return users, nil
+}
+
+// listUsers returns the EMP IDs in the admin tab.
+func listUsers(client store.Client) ([]string, error) {
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.EmpID)
+	}
+	return ids, nil
+}
+
+// userRole returns the role of empID in the admin tab.
+func userRole(client store.Client, empID string) (string, error) {
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		return "", err
+	}
+	for _, u := range users {
+		if u.EmpID == empID {
+			return u.Role, nil
+		}
+	}
+	return roleEmployee, nil
+}
+
+// directReports returns the users whose manager is empID.
+func directReports(users []adminUser, empID string) []string {
+	var reports []string
+	for _, u := range users {
+		if u.Manager == empID {
+			reports = append(reports, u.EmpID)
+		}
+	}
+	return reports
+}
+
+// verifySession exits unless the session token matches the user's current
+// password and the account is still active, so a disabled user or an
+// edited .session is stopped at the next command.
+func verifySession(client store.Client) {
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		log.Fatalf("  Failed to check your session: %v", err)
+	}
+	if err := checkSession(users, internal.CurrentUserID, internal.GetSessionToken()); err != nil {
+		log.Fatalf("  Your session is no longer valid: %v. Please run 'timesheet setup' again.", err)
+	}
+}
+
+// checkSession returns why the session of empID with token is no longer
+// valid, or nil.
+func checkSession(users []adminUser, empID, token string) error {
+	for _, u := range users {
+		if u.EmpID != empID {
+			continue
+		}
+		if token != internal.SessionToken(u.EmpID, u.Password) {
+			return fmt.Errorf("the password of %s has changed", empID)
+		}
+		if u.Status != userActive {
+			return fmt.Errorf("account %s is %s", empID, u.Status)
+		}
+		return nil
+	}
+	return fmt.Errorf("no user '%s' in the admin tab", empID)
+}
+
+// hasAdmin reports whether an active user has the admin role.
+func hasAdmin(users []adminUser) bool {
+	for _, u := range users {
+		if u.Role == roleAdmin && u.Status == userActive {
+			return true
+		}
+	}
+	return false
+}
+
+// claimAdmin gives empID the admin role if no active user has it, which is
+// how sheets from before roles existed get their first admin.
+func claimAdmin(client store.Client, users []adminUser, empID string) error {
+	if hasAdmin(users) {
+		return fmt.Errorf("an admin already exists; ask them to change roles")
+	}
+	for _, u := range users {
+		if u.EmpID == empID {
+			return client.Update(fmt.Sprintf("admin!C%d", u.Row), [][]interface{}{{roleAdmin}}, "RAW")
+		}
+	}
+	return fmt.Errorf("no user '%s' in the admin tab", empID)
+}
+
+// requireRole exits unless empID has one of the roles.
+func requireRole(client store.Client, empID string, roles ...string) {
+	role, err := userRole(client, empID)
+	if err != nil {
+		log.Fatalf("  Failed to read roles: %v", err)
+	}
+	if err := checkRole(empID, role, roles...); err != nil {
+		log.Fatalf("  Not allowed: %v.", err)
+	}
+}
+
+// checkRole returns an error unless role is one of roles.
+func checkRole(empID, role string, roles ...string) error {
+	for _, r := range roles {
+		if role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("this needs the %s role; %s is %s", strings.Join(roles, " or "), empID, role)
+}
+
+// tabExists reports whether the spreadsheet has a tab with title.
+func tabExists(client store.Client, title string) (bool, error) {
+	ss, err := client.Spreadsheet()
+	if err != nil {
+		return false, err
+	}
+	for _, sheet := range ss.Sheets {
+		if sheet.Properties.Title == title {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// adminSheet returns a spreadsheet whose admin tab holds rows from row 2.
+func adminSheet(rows ...[]interface{}) *fakeSheets {
+	client := newFakeSheets()
+	client.tabs["admin"] = append([][]interface{}{{"emp_id", "password", "role", "manager", "status"}}, rows...)
+	return client
+}
+
+func TestUserRole(t *testing.T) {
+	client := adminSheet(
+		[]interface{}{"E1", "pw", "Admin"},
+		[]interface{}{"E2", "pw", "", "E1"},
+		[]interface{}{"E3", "pw", "manager"},
+	)
+	for empID, want := range map[string]string{
+		"E1": roleAdmin,
+		"E2": roleEmployee,
+		"E3": roleManager,
+		// Not in the tab at all.
+		"E9": roleEmployee,
+	} {
+		if got, err := userRole(client, empID); err != nil || got != want {
+			t.Errorf("userRole(%s) = %q, %v; want %q", empID, got, err, want)
+		}
+	}
+}
+
+func TestCheckRole(t *testing.T) {
+	tests := []struct {
+		role  string
+		roles []string
+		ok    bool
+	}{
+		{roleAdmin, []string{roleAdmin}, true},
+		{roleManager, []string{roleManager, roleAdmin}, true},
+		{roleEmployee, []string{roleManager, roleAdmin}, false},
+		{roleManager, []string{roleAdmin}, false},
+	}
+	for _, tt := range tests {
+		if err := checkRole("E1", tt.role, tt.roles...); (err == nil) != tt.ok {
+			t.Errorf("checkRole(%s, %v) = %v, want ok %v", tt.role, tt.roles, err, tt.ok)
+		}
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	client := adminSheet(
+		[]interface{}{"E1", "secret", roleEmployee, "", userActive},
+		[]interface{}{"E2", "secret", roleEmployee, "", userDisabled},
+	)
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		empID string
+		token string
+		ok    bool
+	}{
+		{"valid", "E1", internal.SessionToken("E1", "secret"), true},
+		{"password reset", "E1", internal.SessionToken("E1", "old"), false},
+		{"edited session", "E1", internal.SessionToken("E2", "secret"), false},
+		{"disabled", "E2", internal.SessionToken("E2", "secret"), false},
+		{"deleted from the tab", "E3", internal.SessionToken("E3", "secret"), false},
+	}
+	for _, tt := range tests {
+		if err := checkSession(users, tt.empID, tt.token); (err == nil) != tt.ok {
+			t.Errorf("%s: checkSession = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestClaimAdmin(t *testing.T) {
+	client := adminSheet(
+		[]interface{}{"E1", "pw", roleEmployee},
+		[]interface{}{"E2", "pw", roleAdmin, "", userDisabled},
+	)
+	users, _ := loadAdminUsers(client)
+	if hasAdmin(users) {
+		t.Fatal("a disabled admin counts as an admin")
+	}
+	if err := claimAdmin(client, users, "E9"); err == nil {
+		t.Error("an unknown user claimed the admin role")
+	}
+	if err := claimAdmin(client, users, "E1"); err != nil {
+		t.Fatalf("claimAdmin: %v", err)
+	}
+	if role, _ := userRole(client, "E1"); role != roleAdmin {
+		t.Errorf("E1 is %s after claiming, want admin", role)
+	}
+
+	users, _ = loadAdminUsers(client)
+	if err := claimAdmin(client, users, "E2"); err == nil {
+		t.Error("a second user claimed the admin role")
+	}
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Every approval decision is appended to a shared tab, keeping the history:
+//
+//	approvals: emp_id | week | status | approver | at | reason
+const approvalsSheet = "approvals"
+
+var (
+	reviewAll    bool
+	decisionDate string
+	rejectReason string
+)
+
+type approval struct {
+	EmpID    string
+	Week     string
+	Status   string
+	Approver string
+	At       string
+	Reason   string
+}
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "🧐 List submitted weeks of your direct reports",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		me := internal.CurrentUserID
+		users, err := loadAdminUsers(client)
+		if err != nil {
+			log.Fatalf("  Failed to read users: %v", err)
+		}
+
+		var team []string
+		if reviewAll {
+			requireRole(client, me, roleAdmin)
+			for _, u := range users {
+				team = append(team, u.EmpID)
+			}
+		} else {
+			requireRole(client, me, roleManager, roleAdmin)
+			team = directReports(users, me)
+		}
+		if len(team) == 0 {
+			fmt.Println("ℹ️ Nobody reports to you. Set the manager column in the admin tab.")
+			return
+		}
+
+		subs, err := loadSubmissions(client)
+		if err != nil {
+			log.Fatalf("  Failed to read submissions: %v", err)
+		}
+		var pending []submission
+		for _, s := range subs {
+			if s.Status == statusSubmitted && containsString(team, s.EmpID) {
+				pending = append(pending, s)
+			}
+		}
+		if len(pending) == 0 {
+			fmt.Println("   No weeks waiting for review.")
+			return
+		}
+		sort.Slice(pending, func(i, j int) bool {
+			if pending[i].Week != pending[j].Week {
+				return pending[i].Week < pending[j].Week
+			}
+			return pending[i].EmpID < pending[j].EmpID
+		})
+
+		var owners []string
+		for _, s := range pending {
+			if !containsString(owners, s.EmpID) {
+				owners = append(owners, s.EmpID)
+			}
+		}
+		all, err := fetchUsersEntries(client, owners)
+		if err != nil {
+			log.Fatalf("  Failed to read timesheets: %v", err)
+		}
+
+		for _, s := range pending {
+			monday, _ := time.Parse("2006-01-02", s.Week)
+			sunday := monday.AddDate(0, 0, 6)
+			totals := map[string]float64{}
+			total := 0.0
+			for _, e := range all[s.EmpID] {
+				if e.Date.Before(monday) || e.Date.After(sunday) {
+					continue
+				}
+				totals[e.Bucket] += e.Hours
+				total += e.Hours
+			}
+
+			var parts []string
+			for bucket, hrs := range totals {
+				parts = append(parts, fmt.Sprintf("%s %.1f", bucket, hrs))
+			}
+			sort.Strings(parts)
+			fmt.Printf("📤 %-8s week of %s  %6.1f hrs  (%s)  submitted %s\n", s.EmpID, s.Week, total, strings.Join(parts, ", "), s.UpdatedAt)
+		}
+		fmt.Println("\nUse 'timesheet approve <emp_id>' or 'timesheet reject <emp_id> --reason ...' with --date for the week.")
+	},
+}
+
+var approveCmd = &cobra.Command{
+	Use:   "approve <emp_id>",
+	Short: "✅ Approve a submitted week of a direct report",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		decideWeek(args[0], statusApproved, "")
+	},
+}
+
+var rejectCmd = &cobra.Command{
+	Use:   "reject <emp_id>",
+	Short: "🚫 Reject a submitted week, reopening it with a reason",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if strings.TrimSpace(rejectReason) == "" {
+			fmt.Println("  Please provide --reason.")
+			cmd.Usage()
+			os.Exit(1)
+		}
+		decideWeek(args[0], statusRejected, rejectReason)
+	},
+}
+
+// decideWeek approves or rejects empID's submitted week as the current
+// user, who must be their manager or an admin.
+func decideWeek(empID, status, reason string) {
+	if !internal.IsLoggedIn() {
+		fmt.Println("  Please run 'timesheet setup' first.")
+		os.Exit(1)
+	}
+
+	client := getSheetsClient()
+	me := internal.CurrentUserID
+	if err := canReview(client, me, empID); err != nil {
+		log.Fatalf("  %v", err)
+	}
+
+	monday := weekOf(decisionDate)
+	week := monday.Format("2006-01-02")
+	sub, err := findSubmission(client, empID, monday)
+	if err != nil {
+		log.Fatalf("  Failed to read submissions: %v", err)
+	}
+	if sub == nil || sub.Status != statusSubmitted {
+		fmt.Printf("ℹ️ %s has no submitted week of %s to review.\n", empID, week)
+		os.Exit(1)
+	}
+
+	if err := ensureTab(client, approvalsSheet, []interface{}{"emp_id", "week", "status", "approver", "at", "reason"}); err != nil {
+		log.Fatalf("  Failed to ensure approvals sheet: %v", err)
+	}
+	// The status decides what is locked, so it is written first; the
+	// history row only records it.
+	if err := setSubmission(client, empID, monday, status, me); err != nil {
+		log.Fatalf("  Failed to update submission: %v", err)
+	}
+	decision := []interface{}{empID, week, status, me, time.Now().Format(time.RFC3339), reason}
+	if err := client.Append(approvalsSheet+"!A:F", [][]interface{}{decision}, "RAW"); err != nil {
+		log.Fatalf("  The week of %s is %s, but recording it in '%s' failed: %v\n   Add this row there by hand: %v", week, status, approvalsSheet, err, decision)
+	}
+
+	if status == statusApproved {
+		fmt.Printf("   Approved week of %s for %s.\n", week, empID)
+	} else {
+		fmt.Printf("↩️  Rejected week of %s for %s; it is open again.\n", week, empID)
+	}
+}
+
+// canReview checks that reviewer is an admin, or has the manager role and
+// is empID's manager: the same rule 'review' lists by.
+func canReview(client store.Client, reviewer, empID string) error {
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		return fmt.Errorf("failed to read users: %v", err)
+	}
+	role := roleEmployee
+	for _, u := range users {
+		if u.EmpID == reviewer {
+			role = u.Role
+		}
+	}
+	if reviewer == empID {
+		return fmt.Errorf("you cannot review your own week")
+	}
+	switch role {
+	case roleAdmin:
+		return nil
+	case roleManager:
+	default:
+		return fmt.Errorf("this needs the manager or admin role; %s is %s", reviewer, role)
+	}
+	if !containsString(directReports(users, reviewer), empID) {
+		return fmt.Errorf("%s does not report to you", empID)
+	}
+	return nil
+}
+
+func loadApprovals(client store.Client) ([]approval, error) {
+	found, err := tabExists(client, approvalsSheet)
+	if err != nil || !found {
+		return nil, err
+	}
+	resp, err := client.Get(approvalsSheet + "!A2:F")
+	if err != nil {
+		return nil, err
+	}
+	var approvals []approval
+	for _, row := range resp {
+		if cellString(row, 0) == "" {
+			continue
+		}
+		approvals = append(approvals, approval{
+			EmpID:    cellString(row, 0),
+			Week:     cellString(row, 1),
+			Status:   cellString(row, 2),
+			Approver: cellString(row, 3),
+			At:       cellString(row, 4),
+			Reason:   cellString(row, 5),
+		})
+	}
+	return approvals, nil
+}
+
+// openRejections returns the latest rejection of each of empID's weeks that
+// is still rejected, i.e. not resubmitted yet.
+func openRejections(client store.Client, empID string) ([]approval, error) {
+	subs, err := loadSubmissions(client)
+	if err != nil {
+		return nil, err
+	}
+	rejected := map[string]bool{}
+	for _, s := range subs {
+		if s.EmpID == empID && s.Status == statusRejected {
+			rejected[s.Week] = true
+		}
+	}
+	if len(rejected) == 0 {
+		return nil, nil
+	}
+
+	approvals, err := loadApprovals(client)
+	if err != nil {
+		return nil, err
+	}
+	latest := map[string]approval{}
+	for _, a := range approvals {
+		if a.EmpID == empID && a.Status == statusRejected && rejected[a.Week] {
+			latest[a.Week] = a
+		}
+	}
+	var result []approval
+	for _, a := range latest {
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Week < result[j].Week })
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Shared billing configuration lives in two tabs next to "admin":
+//
+//	buckets: bucket | billable | rate | currency | client | budget_hours | budget_period
+//	rates:   emp_id | bucket   | rate
+//
+// A row in "rates" overrides the bucket's default rate for one user. The
+// budget is shared by everyone logging to the bucket.
+const (
+	bucketSettingsSheet = "buckets"
+	rateOverridesSheet  = "rates"
+	defaultCurrency     = "USD"
+)
+
+var bucketSettingsHeaders = []interface{}{"bucket", "billable", "rate", "currency", "client", "budget_hours", "budget_period"}
+
+type bucketSetting struct {
+	Row      int
+	Name     string
+	Billable bool
+	Rate     float64
+	Currency string
+	Client   string
+	Budget   float64
+	Period   string
+}
+
+type rateOverride struct {
+	Row    int
+	EmpID  string
+	Bucket string
+	Rate   float64
+}
+
+func ensureBillingSheets(client store.Client) error {
+	if err := ensureTab(client, bucketSettingsSheet, bucketSettingsHeaders); err != nil {
+		return err
+	}
+	return ensureTab(client, rateOverridesSheet, []interface{}{"emp_id", "bucket", "rate"})
+}
+
+func loadBucketSettings(client store.Client) (map[string]bucketSetting, error) {
+	resp, err := client.Get(bucketSettingsSheet + "!A2:G")
+	if err != nil {
+		return nil, err
+	}
+
+	settings := map[string]bucketSetting{}
+	for i, row := range resp {
+		name := cellString(row, 0)
+		if name == "" {
+			continue
+		}
+		currency := cellString(row, 3)
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		settings[name] = bucketSetting{
+			Row:      i + 2,
+			Name:     name,
+			Billable: parseBool(cellString(row, 1)),
+			Rate:     parseFloat(cellString(row, 2)),
+			Currency: currency,
+			Client:   cellString(row, 4),
+			Budget:   parseFloat(cellString(row, 5)),
+			Period:   cellString(row, 6),
+		}
+	}
+	return settings, nil
+}
+
+func loadRateOverrides(client store.Client) ([]rateOverride, error) {
+	resp, err := client.Get(rateOverridesSheet + "!A2:C")
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []rateOverride
+	for i, row := range resp {
+		if cellString(row, 0) == "" {
+			continue
+		}
+		overrides = append(overrides, rateOverride{
+			Row:    i + 2,
+			EmpID:  cellString(row, 0),
+			Bucket: cellString(row, 1),
+			Rate:   parseFloat(cellString(row, 2)),
+		})
+	}
+	return overrides, nil
+}
+
+// rateFor returns the hourly rate for empID on a bucket, preferring a
+// per-user override over the bucket default.
+func rateFor(setting bucketSetting, overrides []rateOverride, empID string) float64 {
+	for _, o := range overrides {
+		if o.EmpID == empID && o.Bucket == setting.Name {
+			return o.Rate
+		}
+	}
+	return setting.Rate
+}
+
+func cellString(row []interface{}, i int) string {
+	if i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", row[i]))
+}
+
+func parseBool(s string) bool {
+	switch strings.ToLower(s) {
+	case "true", "yes", "y", "1":
+		return true
+	}
+	return false
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	return f
+}
@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"google.golang.org/api/sheets/v4"
 
 	"github.com/srikanth-karthi/timesheet/internal"
-	"github.com/srikanth-karthi/timesheet/internal/setup"
+	"github.com/srikanth-karthi/timesheet/internal/store"
 )
 
 var bucketCmd = &cobra.Command{
@@ -22,16 +23,16 @@ var bucketCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		srv := getSheetsService()
+		client := getSheetsClient()
 		userSheet := internal.CurrentUserID
 
-		bucketResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!C1:Z1").Do()
-		if err != nil || len(bucketResp.Values) == 0 {
+		bucketResp, err := client.Get(userSheet + "!C1:Z1")
+		if err != nil || len(bucketResp) == 0 {
 			log.Fatalf("  Could not read bucket list.")
 		}
-		buckets := bucketResp.Values[0]
+		buckets := bucketResp[0]
 
-		meta, _ := internal.LoadMeta()
+		meta := loadMeta()
 		active := meta.Active
 
 		if len(args) == 1 {
@@ -49,8 +50,7 @@ var bucketCmd = &cobra.Command{
 				os.Exit(1)
 			}
 
-			meta.Active = target
-			_ = internal.SaveMeta(meta)
+			updateMeta(meta, setActive(target))
 			log.Printf("   Switched to bucket: %s", target)
 			return
 		}
@@ -78,23 +78,23 @@ var bucketListCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		srv := getSheetsService()
+		client := getSheetsClient()
 		userSheet := internal.CurrentUserID
 
-		bucketResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!C1:Z1").Do()
+		bucketResp, err := client.Get(userSheet + "!C1:Z1")
 		if err != nil {
 			log.Fatalf("  Failed to fetch buckets: %v", err)
 		}
 
-		meta, _ := internal.LoadMeta()
+		meta := loadMeta()
 		active := meta.Active
 
-		if len(bucketResp.Values) == 0 {
+		if len(bucketResp) == 0 {
 			fmt.Println("ℹ️ No buckets found.")
 			return
 		}
 
-		for _, cell := range bucketResp.Values[0] {
+		for _, cell := range bucketResp[0] {
 			name := cell.(string)
 			prefix := "  "
 			colorStart, colorEnd := "", ""
@@ -120,14 +120,14 @@ var bucketNewCmd = &cobra.Command{
 
 		bucket := args[0]
 		userSheet := internal.CurrentUserID
-		srv := getSheetsService()
+		client := getSheetsClient()
 
-		resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!C1:Z1").Do()
+		resp, err := client.Get(userSheet + "!C1:Z1")
 		if err != nil {
 			log.Fatalf("  Failed to read meta-buckets row: %v", err)
 		}
 
-		row := resp.Values
+		row := resp
 		found := false
 		numBuckets := 0
 
@@ -148,34 +148,182 @@ var bucketNewCmd = &cobra.Command{
 			colLetter := columnNumberToLetter(newCol)
 			cellRef := fmt.Sprintf("%s1", colLetter)
 
-			_, err = srv.Spreadsheets.Values.Update(spreadsheetID, userSheet+"!"+cellRef, &sheets.ValueRange{
-				Values: [][]interface{}{{bucket}},
-			}).ValueInputOption("RAW").Do()
+			err = client.Update(userSheet+"!"+cellRef, [][]interface{}{{bucket}}, "RAW")
 			if err != nil {
 				log.Fatalf("  Failed to append new bucket: %v", err)
 			}
 			log.Printf("🌟 Created new bucket: %s", bucket)
 		}
 
-		meta, _ := internal.LoadMeta()
-		meta.Active = bucket
-		_ = internal.SaveMeta(meta)
+		updateMeta(loadMeta(), setActive(bucket))
 
 		log.Printf("   Switched to bucket: %s", bucket)
 	},
 }
 
+var (
+	bucketBillable bool
+	bucketRate     float64
+	bucketCurrency string
+	bucketClient   string
+	bucketRateUser string
+	bucketBudget   float64
+	bucketPeriod   string
+)
+
+var bucketSetCmd = &cobra.Command{
+	Use:   "set [name]",
+	Short: "💰 Set billable status, hourly rate, client and budget for a bucket (admin only)",
+	Long: `Set billing details for a bucket. Rates and budgets are shared by
+everyone and need the admin role.
+
+Examples:
+  timesheet bucket set acme --billable --rate 120 --currency EUR --client "Acme Corp"
+  timesheet bucket set acme --user E042 --rate 150   # per-user rate override
+  timesheet bucket set acme --budget 120 --budget-period month`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		bucket := args[0]
+		client := getSheetsClient()
+		// Rates and budgets are shared by everyone and feed invoices.
+		requireRole(client, internal.CurrentUserID, roleAdmin)
+
+		if err := ensureBillingSheets(client); err != nil {
+			log.Fatalf("  Failed to ensure billing sheets: %v", err)
+		}
+
+		if bucketRateUser != "" {
+			if !cmd.Flags().Changed("rate") {
+				log.Fatalf("  --user requires --rate")
+			}
+			overrides, err := loadRateOverrides(client)
+			if err != nil {
+				log.Fatalf("  Failed to read rate overrides: %v", err)
+			}
+			values := [][]interface{}{{bucketRateUser, bucket, bucketRate}}
+			for _, o := range overrides {
+				if o.EmpID == bucketRateUser && o.Bucket == bucket {
+					err = client.Update(fmt.Sprintf("%s!A%d:C%d", rateOverridesSheet, o.Row, o.Row), values, "RAW")
+					if err != nil {
+						log.Fatalf("  Failed to update rate override: %v", err)
+					}
+					fmt.Printf("💰 Rate for %s on '%s' set to %.2f\n", bucketRateUser, bucket, bucketRate)
+					return
+				}
+			}
+			err = client.Append(rateOverridesSheet+"!A2:C", values, "RAW")
+			if err != nil {
+				log.Fatalf("  Failed to add rate override: %v", err)
+			}
+			fmt.Printf("💰 Rate for %s on '%s' set to %.2f\n", bucketRateUser, bucket, bucketRate)
+			return
+		}
+
+		settings, err := loadBucketSettings(client)
+		if err != nil {
+			log.Fatalf("  Failed to read bucket settings: %v", err)
+		}
+
+		setting, exists := settings[bucket]
+		if !exists {
+			setting = bucketSetting{Name: bucket, Currency: defaultCurrency}
+		}
+		if cmd.Flags().Changed("billable") {
+			setting.Billable = bucketBillable
+		}
+		if cmd.Flags().Changed("rate") {
+			setting.Rate = bucketRate
+		}
+		if cmd.Flags().Changed("currency") {
+			setting.Currency = strings.ToUpper(bucketCurrency)
+		}
+		if cmd.Flags().Changed("client") {
+			setting.Client = bucketClient
+		}
+		if cmd.Flags().Changed("budget") {
+			setting.Budget = bucketBudget
+		}
+		if cmd.Flags().Changed("budget-period") {
+			switch bucketPeriod {
+			case budgetTotal, budgetMonth, budgetWeek:
+			default:
+				log.Fatalf("  Invalid --budget-period '%s'. Use total, month or week.", bucketPeriod)
+			}
+			setting.Period = bucketPeriod
+		}
+
+		if cmd.Flags().Changed("budget") {
+			// Tabs created before budgets existed lack these headers.
+			err = client.Update(bucketSettingsSheet+"!F1:G1", [][]interface{}{bucketSettingsHeaders[5:]}, "RAW")
+			if err != nil {
+				log.Fatalf("  Failed to update bucket settings headers: %v", err)
+			}
+		}
+
+		values := [][]interface{}{
+			{setting.Name, strconv.FormatBool(setting.Billable), setting.Rate, setting.Currency, setting.Client, budgetCell(setting.Budget), setting.Period},
+		}
+		if exists {
+			err = client.Update(fmt.Sprintf("%s!A%d:G%d", bucketSettingsSheet, setting.Row, setting.Row), values, "RAW")
+		} else {
+			err = client.Append(bucketSettingsSheet+"!A2:G", values, "RAW")
+		}
+		if err != nil {
+			log.Fatalf("  Failed to save bucket settings: %v", err)
+		}
+		forgetBudget(bucket)
+
+		billable := "non-billable"
+		if setting.Billable {
+			billable = "billable"
+		}
+		fmt.Printf("💰 Bucket '%s' is %s at %.2f %s/hr", bucket, billable, setting.Rate, setting.Currency)
+		if setting.Client != "" {
+			fmt.Printf(" for %s", setting.Client)
+		}
+		fmt.Println()
+		if setting.Budget > 0 {
+			fmt.Printf("📊 Budget: %.1f hrs %s\n", setting.Budget, periodLabel(setting.Period))
+		}
+	},
+}
+
 func columnNumberToLetter(n int) string {
 	letters := ""
 	for n > 0 {
 		n--
-		letters = string('A'+(n%26)) + letters
+		letters = string(rune('A'+(n%26))) + letters
 		n /= 26
 	}
 	return letters
 }
 
-func getSheetsService() *sheets.Service {
-	provider := setup.GetCredentialProvider()
-	return setup.GetSheetsService(provider)
+func setActive(bucket string) func(m *internal.Meta) error {
+	return func(m *internal.Meta) error {
+		m.Active = bucket
+		return nil
+	}
+}
+
+// bucketExists reports whether name is one of the buckets in row 1 of the
+// user's sheet.
+func bucketExists(client store.Client, userSheet, name string) (bool, error) {
+	resp, err := client.Get(userSheet + "!C1:Z1")
+	if err != nil {
+		return false, err
+	}
+	if len(resp) == 0 {
+		return false, nil
+	}
+	for _, cell := range resp[0] {
+		if fmt.Sprint(cell) == name {
+			return true, nil
+		}
+	}
+	return false, nil
 }
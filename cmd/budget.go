@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Budget periods: the budget covers all time, each calendar month or each
+// Monday to Sunday week.
+const (
+	budgetTotal = "total"
+	budgetMonth = "month"
+	budgetWeek  = "week"
+)
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "📊 Show hour budgets and burn-down across all buckets",
+	Long: `Show the hour budget of every bucket that has one, with the hours
+logged against it by all users in the current period.
+
+Set budgets with 'timesheet bucket set <name> --budget <hours>'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		usages, err := budgetUsages(client, nil)
+		if err != nil {
+			log.Fatalf("  Failed to compute budgets: %v", err)
+		}
+		if len(usages) == 0 {
+			fmt.Println("ℹ️ No bucket has a budget. Set one with 'timesheet bucket set <name> --budget <hours>'.")
+			return
+		}
+
+		now := time.Now().In(userLocation())
+		for _, u := range usages {
+			fmt.Printf("%-12s %-26s %7.1f / %-7.1f hrs %4.0f%% %s\n", u.Bucket, budgetBar(u.Percent()), u.Used, u.Budget, u.Percent(), periodLabel(u.Period))
+
+			// Project when the budget runs out at the pace of this period.
+			elapsed := now.Sub(u.Since).Hours() / 24
+			if u.Since.IsZero() || elapsed < 1 || u.Used <= 0 || u.Remaining() <= 0 {
+				if u.Remaining() <= 0 {
+					fmt.Printf("             🚨 over budget by %.1f hrs\n", -u.Remaining())
+				}
+				continue
+			}
+			perDay := u.Used / elapsed
+			runsOut := now.AddDate(0, 0, int(u.Remaining()/perDay))
+			fmt.Printf("             %.1f hrs left, burning %.1f hrs/week → runs out around %s\n", u.Remaining(), perDay*7, runsOut.Format("Jan 02"))
+		}
+	},
+}
+
+// budgetUsage is the hours logged against a bucket's budget in its current
+// period.
+type budgetUsage struct {
+	Bucket string
+	Budget float64
+	Period string
+	Since  time.Time
+	Used   float64
+}
+
+func (u budgetUsage) Remaining() float64 {
+	return u.Budget - u.Used
+}
+
+func (u budgetUsage) Percent() float64 {
+	return u.Used / u.Budget * 100
+}
+
+// periodStart is the first day counted for a budget period, zero for total.
+func periodStart(period string, now time.Time) time.Time {
+	switch period {
+	case budgetMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case budgetWeek:
+		return weekDays(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))[0]
+	}
+	return time.Time{}
+}
+
+func periodLabel(period string) string {
+	switch period {
+	case budgetMonth:
+		return "this month"
+	case budgetWeek:
+		return "this week"
+	}
+	return "in total"
+}
+
+// budgetUsages sums the hours of all users against every bucket budget, or
+// only the given buckets when names is not empty, sorted by bucket.
+func budgetUsages(client store.Client, names []string) ([]budgetUsage, error) {
+	settings, err := loadBucketSettings(client)
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []budgetUsage
+	now := time.Now().In(userLocation())
+	for name, s := range settings {
+		if s.Budget <= 0 || (len(names) > 0 && !containsString(names, name)) {
+			continue
+		}
+		usages = append(usages, budgetUsage{Bucket: name, Budget: s.Budget, Period: s.Period, Since: periodStart(s.Period, now)})
+	}
+	if len(usages) == 0 {
+		return nil, nil
+	}
+
+	all, err := fetchAllEntries(client)
+	if err != nil {
+		return nil, err
+	}
+	for i := range usages {
+		u := &usages[i]
+		for _, entries := range all {
+			for _, e := range entries {
+				if e.Bucket == u.Bucket && !e.Date.Before(u.Since) {
+					u.Used += e.Hours
+				}
+			}
+		}
+		if u.Since.IsZero() {
+			u.Since = firstEntryDate(all, u.Bucket)
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Bucket < usages[j].Bucket })
+	return usages, nil
+}
+
+// budgetCacheTTL is how long budgetWarnings trusts the usage it last read.
+// Budgets sum every user's sheet, too slow to repeat on every 'log',
+// 'start' and 'stop'; in between, the hours this machine logs are added.
+const budgetCacheTTL = 10 * time.Minute
+
+// cachedBudget is the usage of one bucket as last read, kept in
+// budgets.json. Budget is 0 for buckets without one.
+type cachedBudget struct {
+	budgetUsage
+	Checked time.Time
+}
+
+func budgetCachePath() string {
+	return internal.ConfigPath("budgets.json")
+}
+
+func loadBudgetCache() map[string]cachedBudget {
+	cache := map[string]cachedBudget{}
+	if data, err := os.ReadFile(budgetCachePath()); err == nil {
+		json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+func saveBudgetCache(cache map[string]cachedBudget) {
+	if data, err := json.Marshal(cache); err == nil {
+		internal.WriteFileAtomic(budgetCachePath(), data, 0644)
+	}
+}
+
+// forgetBudget drops the cached usage of bucket, after its budget changed.
+func forgetBudget(bucket string) {
+	cache := loadBudgetCache()
+	if _, ok := cache[bucket]; ok {
+		delete(cache, bucket)
+		saveBudgetCache(cache)
+	}
+}
+
+// budgetWarnings prints the budget state of bucket after added hours dated
+// day were logged to it, and warns once a configured threshold is reached.
+// The sheets are only read when the cached usage is older than
+// budgetCacheTTL. Errors are only logged so a budget lookup never blocks
+// logging time.
+func budgetWarnings(client store.Client, bucket string, added float64, day time.Time) {
+	now := time.Now().In(userLocation())
+	cache := loadBudgetCache()
+	c, ok := cache[bucket]
+	start := periodStart(c.Period, now)
+	if ok && now.Sub(c.Checked) < budgetCacheTTL && (start.IsZero() || c.Since.Equal(start)) {
+		if c.Budget > 0 && !dateOf(day).Before(start) {
+			c.Used += added
+		}
+	} else {
+		usages, err := budgetUsages(client, []string{bucket})
+		if err != nil {
+			log.Printf("⚠️ Could not check budget: %v", err)
+			return
+		}
+		c = cachedBudget{budgetUsage: budgetUsage{Bucket: bucket}, Checked: now}
+		if len(usages) > 0 {
+			c.budgetUsage = usages[0]
+		}
+	}
+	cache[bucket] = c
+	saveBudgetCache(cache)
+	if c.Budget <= 0 {
+		return
+	}
+	u := c.budgetUsage
+
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		cfg = &internal.Config{}
+	}
+	reached := 0.0
+	for _, t := range cfg.BudgetThresholds() {
+		if u.Percent() >= t && t > reached {
+			reached = t
+		}
+	}
+
+	switch {
+	case reached >= 100:
+		fmt.Printf("🚨 Budget for '%s' exceeded: %.1f of %.1f hrs %s (%.0f%%)\n", u.Bucket, u.Used, u.Budget, periodLabel(u.Period), u.Percent())
+	case reached > 0:
+		fmt.Printf("⚠️  Budget for '%s' at %.0f%%: %.1f of %.1f hrs %s, %.1f left\n", u.Bucket, u.Percent(), u.Used, u.Budget, periodLabel(u.Period), u.Remaining())
+	default:
+		fmt.Printf("📊 Budget for '%s': %.1f of %.1f hrs %s, %.1f left\n", u.Bucket, u.Used, u.Budget, periodLabel(u.Period), u.Remaining())
+	}
+}
+
+// fetchAllEntries reads the entries of every user that has a sheet in one
+// batched request, keyed by EMP ID.
+func fetchAllEntries(client store.Client) (map[string][]sheetEntry, error) {
+	users, err := listUsers(client)
+	if err != nil {
+		return nil, err
+	}
+	return fetchUsersEntries(client, users)
+}
+
+// fetchUsersEntries reads the entries of the given users in one batched
+// request. Deleted users are read from their archived sheet; users without
+// a sheet are left out. Parallel time is shared as in 'report'.
+func fetchUsersEntries(client store.Client, users []string) (map[string][]sheetEntry, error) {
+	ss, err := client.Spreadsheet()
+	if err != nil {
+		return nil, err
+	}
+	tabs := map[string]bool{}
+	for _, sheet := range ss.Sheets {
+		tabs[sheet.Properties.Title] = true
+	}
+
+	var ranges []string
+	var owners []string
+	for _, u := range users {
+		switch {
+		case tabs[u]:
+			ranges = append(ranges, u+entriesRange)
+		case tabs[archivePrefix+u]:
+			ranges = append(ranges, archivePrefix+u+entriesRange)
+		default:
+			continue
+		}
+		owners = append(owners, u)
+	}
+	if len(ranges) == 0 {
+		return map[string][]sheetEntry{}, nil
+	}
+
+	resp, err := client.BatchGet(ranges)
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string][]sheetEntry, len(owners))
+	for i, values := range resp {
+		entries := parseEntries(values)
+		applyParallelPolicy(entries)
+		all[owners[i]] = entries
+	}
+	return all, nil
+}
+
+func firstEntryDate(all map[string][]sheetEntry, bucket string) time.Time {
+	var first time.Time
+	for _, entries := range all {
+		for _, e := range entries {
+			if e.Bucket == bucket && (first.IsZero() || e.Date.Before(first)) {
+				first = e.Date
+			}
+		}
+	}
+	return first
+}
+
+func budgetBar(percent float64) string {
+	const width = 20
+	filled := int(percent / 100 * width)
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// budgetCell writes an unset budget as an empty cell.
+func budgetCell(hours float64) string {
+	if hours <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(hours, 'f', -1, 64)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/setup"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+func daemonSocket() string {
+	return internal.ConfigPath("run", "daemon.sock")
+}
+
+// getSheetsClient returns the client for commands of a logged in user,
+// whose session is checked against the admin tab first.
+func getSheetsClient() store.Client {
+	client := sheetsClient()
+	if internal.IsLoggedIn() {
+		verifySession(client)
+	}
+	return client
+}
+
+// sheetsClient talks to the running daemon when there is one and falls
+// back to a direct Sheets connection otherwise.
+func sheetsClient() store.Client {
+	if remote, ok := store.Dial(daemonSocket()); ok {
+		return remote
+	}
+	provider := setup.GetCredentialProvider()
+	return store.NewDirect(setup.GetSheetsService(provider), spreadsheetID)
+}
+
+// temporaryFailure reports whether err is a Sheets call that gave up on a
+// rate limit or outage, so trying again later may succeed.
+func temporaryFailure(err error) bool {
+	var sheetsErr *store.Error
+	return errors.As(err, &sheetsErr) && sheetsErr.Temporary
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// fakeSheets is an in-memory store.Client. Tabs hold their rows from row 1;
+// calls records every write as "<op> <range>" in order.
+type fakeSheets struct {
+	tabs     map[string][][]interface{}
+	ids      map[string]int64
+	calls    []string
+	requests []*sheets.Request
+}
+
+func newFakeSheets() *fakeSheets {
+	return &fakeSheets{tabs: map[string][][]interface{}{}, ids: map[string]int64{}}
+}
+
+// useTempConfig keeps meta.json and config.json of a test in a fresh
+// directory, with dates in UTC.
+func useTempConfig(t *testing.T) {
+	saved := internal.ConfigPath()
+	internal.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { internal.SetConfigDir(saved) })
+	if err := internal.SaveConfig(&internal.Config{Timezone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeRange is a parsed A1 range; an open end is 0.
+type fakeRange struct {
+	tab                        string
+	col, row, lastCol, lastRow int
+}
+
+func parseFakeRange(rng string) fakeRange {
+	tab, cells, _ := strings.Cut(rng, "!")
+	r := fakeRange{tab: tab}
+	from, to, _ := strings.Cut(cells, ":")
+	r.col, r.row = parseFakeCell(from)
+	if to == "" {
+		r.lastCol, r.lastRow = r.col, r.row
+	} else {
+		r.lastCol, r.lastRow = parseFakeCell(to)
+	}
+	return r
+}
+
+// parseFakeCell reads a cell like "E7" as 1-based column and row.
+func parseFakeCell(cell string) (col, row int) {
+	i := 0
+	for i < len(cell) && cell[i] >= 'A' && cell[i] <= 'Z' {
+		col = col*26 + int(cell[i]-'A'+1)
+		i++
+	}
+	fmt.Sscanf(cell[i:], "%d", &row)
+	return col, row
+}
+
+func (f *fakeSheets) Get(rng string) ([][]interface{}, error) {
+	r := parseFakeRange(rng)
+	rows := f.tabs[r.tab]
+	var out [][]interface{}
+	for i := r.row - 1; i < len(rows); i++ {
+		if r.lastRow > 0 && i >= r.lastRow {
+			break
+		}
+		var cells []interface{}
+		for c := r.col - 1; c < len(rows[i]) && (r.lastCol == 0 || c < r.lastCol); c++ {
+			cells = append(cells, rows[i][c])
+		}
+		out = append(out, cells)
+	}
+	return out, nil
+}
+
+func (f *fakeSheets) BatchGet(ranges []string) ([][][]interface{}, error) {
+	var out [][][]interface{}
+	for _, rng := range ranges {
+		values, _ := f.Get(rng)
+		out = append(out, values)
+	}
+	return out, nil
+}
+
+// Append writes rows after the last row, below the four header rows of a
+// user sheet.
+func (f *fakeSheets) Append(rng string, rows [][]interface{}, inputOption string) error {
+	f.calls = append(f.calls, "append "+rng)
+	r := parseFakeRange(rng)
+	tab := f.tabs[r.tab]
+	for len(tab) < 4 {
+		tab = append(tab, nil)
+	}
+	f.tabs[r.tab] = append(tab, rows...)
+	return nil
+}
+
+func (f *fakeSheets) Update(rng string, rows [][]interface{}, inputOption string) error {
+	f.calls = append(f.calls, "update "+rng)
+	r := parseFakeRange(rng)
+	tab := f.tabs[r.tab]
+	for i, row := range rows {
+		for len(tab) < r.row+i {
+			tab = append(tab, nil)
+		}
+		for c, cell := range row {
+			for len(tab[r.row+i-1]) < r.col+c {
+				tab[r.row+i-1] = append(tab[r.row+i-1], "")
+			}
+			tab[r.row+i-1][r.col+c-1] = cell
+		}
+	}
+	f.tabs[r.tab] = tab
+	return nil
+}
+
+func (f *fakeSheets) BatchUpdate(data []*sheets.ValueRange, inputOption string) error {
+	for _, vr := range data {
+		if err := f.Update(vr.Range, vr.Values, inputOption); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeSheets) Spreadsheet() (*sheets.Spreadsheet, error) {
+	var ss sheets.Spreadsheet
+	for name := range f.tabs {
+		ss.Sheets = append(ss.Sheets, &sheets.Sheet{Properties: &sheets.SheetProperties{Title: name, SheetId: f.ids[name]}})
+	}
+	return &ss, nil
+}
+
+// BatchUpdateSpreadsheet records the requests and applies the ones adding
+// and deleting tabs; added tabs get a sheet id not used before.
+func (f *fakeSheets) BatchUpdateSpreadsheet(req *sheets.BatchUpdateSpreadsheetRequest) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	f.calls = append(f.calls, "batchUpdate")
+	f.requests = append(f.requests, req.Requests...)
+
+	resp := &sheets.BatchUpdateSpreadsheetResponse{}
+	for _, r := range req.Requests {
+		reply := &sheets.Response{}
+		switch {
+		case r.DeleteSheet != nil:
+			for name, id := range f.ids {
+				if id == r.DeleteSheet.SheetId {
+					delete(f.tabs, name)
+					delete(f.ids, name)
+				}
+			}
+		case r.AddSheet != nil:
+			props := *r.AddSheet.Properties
+			props.SheetId = int64(len(f.calls)*100 + len(resp.Replies) + 1)
+			f.tabs[props.Title] = nil
+			f.ids[props.Title] = props.SheetId
+			reply.AddSheet = &sheets.AddSheetResponse{Properties: &props}
+		}
+		resp.Replies = append(resp.Replies, reply)
+	}
+	return resp, nil
+}
+
+// cell returns the value at a 1-based row and column of tab, or "".
+func (f *fakeSheets) cell(tab string, row, col int) interface{} {
+	rows := f.tabs[tab]
+	if row > len(rows) || col > len(rows[row-1]) {
+		return ""
+	}
+	return rows[row-1][col-1]
+}
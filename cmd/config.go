@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// configKey exposes one field of the local config to 'timesheet config'.
+type configKey struct {
+	help string
+	get  func(c *internal.Config) string
+	set  func(c *internal.Config, v string) error
+}
+
+var configKeys = map[string]configKey{
+	"timezone": {
+		help: "IANA timezone used for dates, e.g. Europe/Berlin (default: system)",
+		get:  func(c *internal.Config) string { return c.Location().String() },
+		set: func(c *internal.Config, v string) error {
+			if _, err := time.LoadLocation(v); err != nil {
+				return err
+			}
+			c.Timezone = v
+			return nil
+		},
+	},
+	"parallel-time": {
+		help: "How reports count time of parallel timers: overlap (double-count) or split",
+		get:  func(c *internal.Config) string { return c.ParallelPolicy() },
+		set: func(c *internal.Config, v string) error {
+			if v != internal.ParallelOverlap && v != internal.ParallelSplit {
+				return fmt.Errorf("expected %s or %s", internal.ParallelOverlap, internal.ParallelSplit)
+			}
+			c.ParallelTime = v
+			return nil
+		},
+	},
+	"max-session-hours": {
+		help: "Sessions longer than this make 'stop' ask how to trim them",
+		get:  func(c *internal.Config) string { return strconv.FormatFloat(c.MaxSession().Hours(), 'f', -1, 64) },
+		set: func(c *internal.Config, v string) error {
+			hours, err := strconv.ParseFloat(v, 64)
+			if err != nil || hours <= 0 {
+				return fmt.Errorf("expected a positive number of hours")
+			}
+			c.MaxSessionHours = hours
+			return nil
+		},
+	},
+	"hours-per-day": {
+		help: "Hours expected on a working day, used for expected vs actual in 'report'",
+		get:  func(c *internal.Config) string { return strconv.FormatFloat(c.DailyHours(), 'f', -1, 64) },
+		set: func(c *internal.Config, v string) error {
+			hours, err := strconv.ParseFloat(v, 64)
+			if err != nil || hours <= 0 || hours > 24 {
+				return fmt.Errorf("expected a number of hours between 0 and 24")
+			}
+			c.HoursPerDay = hours
+			return nil
+		},
+	},
+	"hours-per-week": {
+		help: "Contracted hours per week, spread over the work days when hours-per-day is unset",
+		get:  func(c *internal.Config) string { return strconv.FormatFloat(c.HoursPerWeek, 'f', -1, 64) },
+		set: func(c *internal.Config, v string) error {
+			hours, err := strconv.ParseFloat(v, 64)
+			if err != nil || hours < 0 || hours > 168 {
+				return fmt.Errorf("expected a number of hours between 0 and 168")
+			}
+			c.HoursPerWeek = hours
+			return nil
+		},
+	},
+	"weekend-work": {
+		help: "Hours logged on non-working days: count (as overtime) or ignore",
+		get:  func(c *internal.Config) string { return c.WeekendPolicy() },
+		set: func(c *internal.Config, v string) error {
+			if v != internal.WeekendCount && v != internal.WeekendIgnore {
+				return fmt.Errorf("expected %s or %s", internal.WeekendCount, internal.WeekendIgnore)
+			}
+			c.WeekendRule = v
+			return nil
+		},
+	},
+	"holidays": {
+		help: "Public holidays are a day off (off) or a normal working day (workday)",
+		get:  func(c *internal.Config) string { return c.HolidayPolicy() },
+		set: func(c *internal.Config, v string) error {
+			if v != internal.HolidayOff && v != internal.HolidayWorkday {
+				return fmt.Errorf("expected %s or %s", internal.HolidayOff, internal.HolidayWorkday)
+			}
+			c.HolidayRule = v
+			return nil
+		},
+	},
+	"overtime-since": {
+		help: "Day the overtime balance starts from, yyyy-mm-dd (default: first entry)",
+		get:  func(c *internal.Config) string { return c.OvertimeSince },
+		set: func(c *internal.Config, v string) error {
+			if v != "" {
+				if _, err := time.Parse("2006-01-02", v); err != nil {
+					return fmt.Errorf("expected yyyy-mm-dd")
+				}
+			}
+			c.OvertimeSince = v
+			return nil
+		},
+	},
+	"budget-warn": {
+		help: "Comma separated budget percentages to warn at, e.g. 80,100",
+		get: func(c *internal.Config) string {
+			var parts []string
+			for _, t := range c.BudgetThresholds() {
+				parts = append(parts, strconv.FormatFloat(t, 'f', -1, 64))
+			}
+			return strings.Join(parts, ",")
+		},
+		set: func(c *internal.Config, v string) error {
+			var thresholds []float64
+			for _, part := range strings.Split(v, ",") {
+				t, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(part, "%")), 64)
+				if err != nil || t <= 0 {
+					return fmt.Errorf("expected percentages like 80,100")
+				}
+				thresholds = append(thresholds, t)
+			}
+			c.BudgetWarn = thresholds
+			return nil
+		},
+	},
+	"ticket-prefixes": {
+		help: "Comma separated project keys detected as tickets, e.g. PROJ,OPS (default: any)",
+		get:  func(c *internal.Config) string { return strings.Join(c.TicketPrefixes, ",") },
+		set: func(c *internal.Config, v string) error {
+			var prefixes []string
+			for _, part := range strings.Split(v, ",") {
+				if p := strings.ToUpper(strings.TrimSpace(part)); p != "" {
+					prefixes = append(prefixes, p)
+				}
+			}
+			c.TicketPrefixes = prefixes
+			return nil
+		},
+	},
+	"work-days": {
+		help: "Weekdays you are expected to work, e.g. mon-fri or mon-thu",
+		get: func(c *internal.Config) string {
+			if c.WorkDays == "" {
+				return "mon-fri"
+			}
+			return c.WorkDays
+		},
+		set: func(c *internal.Config, v string) error {
+			if _, err := internal.ParseWeekdays(v); err != nil {
+				return err
+			}
+			c.WorkDays = v
+			return nil
+		},
+	},
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "⚙️ Show or change local settings",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+
+		names := make([]string, 0, len(configKeys))
+		for name := range configKeys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := configKeys[name]
+			fmt.Printf("%-20s = %-10s # %s\n", name, key.get(cfg), key.help)
+		}
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Change a setting",
+	Args:  cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var names []string
+		for name := range configKeys {
+			names = append(names, name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		key, ok := configKeys[args[0]]
+		if !ok {
+			fmt.Printf("  Unknown setting '%s'. Run 'timesheet config' to list them.\n", args[0])
+			os.Exit(1)
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		if err := key.set(cfg, args[1]); err != nil {
+			log.Fatalf("  Invalid value for %s: %v", args[0], err)
+		}
+		if err := internal.SaveConfig(cfg); err != nil {
+			log.Fatalf("  Failed to save config: %v", err)
+		}
+		fmt.Printf("⚙️  %s = %s\n", args[0], key.get(cfg))
+	},
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/setup"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+var (
+	daemonFlushInterval time.Duration
+	daemonCacheTTL      time.Duration
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "🛰️ Run a background daemon that keeps the sheet connection warm",
+	Long: `Run the timesheet daemon in the foreground.
+
+While it runs, other timesheet commands send their Sheets requests over a
+local Unix socket instead of authenticating and re-reading the sheet on
+every invocation. Reads of your own sheet are cached and writes to it
+batched; shared tabs such as admin, submissions and approvals always go
+straight to Google Sheets, since other machines change them. Commands fall
+back to talking to Google Sheets directly when the daemon is not running.
+
+Run it in the background with e.g. 'timesheet daemon &' or a launchd/systemd unit.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		provider := setup.GetCredentialProvider()
+		direct := store.NewDirect(setup.GetSheetsService(provider), spreadsheetID)
+
+		os.MkdirAll(internal.ConfigPath(), 0755)
+		// Only the logged in user's own sheet is cached; restart the daemon
+		// after logging in as someone else.
+		server := store.NewServer(direct, internal.GetSessionUser(), daemonFlushInterval, daemonCacheTTL)
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			log.Printf("🛑 Shutting down, flushing pending writes...")
+			server.Close()
+		}()
+
+		go watchSession()
+
+		log.Printf("🛰️ Daemon listening on %s", daemonSocket())
+		if err := server.Serve(daemonSocket()); err != nil {
+			log.Fatalf("  Daemon stopped: %v", err)
+		}
+		os.Remove(daemonSocket())
+		log.Printf("   Daemon stopped.")
+	},
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote, ok := store.Dial(daemonSocket())
+		if !ok {
+			fmt.Println("⚪ Daemon is not running; commands talk to Google Sheets directly.")
+			return
+		}
+		st, err := remote.Status()
+		if err != nil {
+			log.Fatalf("  Failed to query daemon: %v", err)
+		}
+		fmt.Printf("🟢 Daemon running (pid %d) since %s\n", st.PID, st.Started.Format("02/01/06 15:04"))
+		fmt.Printf("   %d cached ranges, %d pending writes\n", st.CachedRanges, st.PendingWrites)
+	},
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Flush pending writes and stop the daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote, ok := store.Dial(daemonSocket())
+		if !ok {
+			fmt.Println("⚪ Daemon is not running.")
+			return
+		}
+		if err := remote.Shutdown(); err != nil {
+			log.Fatalf("  Failed to stop daemon: %v", err)
+		}
+		fmt.Println("🛑 Daemon stopped.")
+	},
+}
+
+// watchSession warns once per timer when a running session gets longer
+// than the configured maximum or crosses midnight, so a forgotten 'stop'
+// shows up in the daemon log instead of as a 15 hour entry.
+func watchSession() {
+	warned := map[string]bool{}
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		meta, err := internal.LoadMeta()
+		if err != nil {
+			log.Printf("⚠️ %v", err)
+			continue
+		}
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			cfg = &internal.Config{}
+		}
+
+		now := time.Now()
+		for _, id := range meta.TimerIDs() {
+			timer := meta.Timers[id]
+			start, err := time.Parse(time.RFC3339, timer.Start)
+			if err != nil || warned[timer.Start] {
+				continue
+			}
+			if now.Sub(start) > cfg.MaxSession() {
+				log.Printf("⚠️ Timer '%s' started %s has been running %.1f hrs. Forgot to 'timesheet stop'?", id, start.In(cfg.Location()).Format("Mon 15:04"), now.Sub(start).Hours())
+				warned[timer.Start] = true
+			} else if len(internal.SplitByDay(start, now, cfg.Location())) > 1 {
+				log.Printf("⚠️ Timer '%s' started %s is still running past midnight.", id, start.In(cfg.Location()).Format("Mon 15:04"))
+				warned[timer.Start] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+var (
+	entriesTicket string
+	entriesBucket string
+	entriesTag    string
+)
+
+var entriesCmd = &cobra.Command{
+	Use:   "entries",
+	Short: "📋 List logged entries, optionally filtered by ticket, bucket or tag",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		entries, err := fetchEntries(client, internal.CurrentUserID)
+		if err != nil {
+			log.Fatalf("  Failed to fetch timesheet data: %v", err)
+		}
+
+		ticket := internal.NormalizeTicket(entriesTicket)
+		tag := internal.NormalizeTags([]string{entriesTag})
+
+		total := 0.0
+		count := 0
+		for _, e := range entries {
+			if ticket != "" && e.Ticket != ticket {
+				continue
+			}
+			if entriesBucket != "" && e.Bucket != entriesBucket {
+				continue
+			}
+			if len(tag) > 0 && !e.hasAnyTag(tag) {
+				continue
+			}
+
+			fmt.Printf("%4d  %s  %-10s %-10s %5.2f hrs  %s", e.Row, e.Date.Format("02/01/06"), e.Bucket, e.Ticket, e.Hours, e.Task)
+			if len(e.Tags) > 0 {
+				fmt.Printf("  [%s]", strings.Join(e.Tags, ", "))
+			}
+			fmt.Println()
+			total += e.Hours
+			count++
+		}
+
+		if count == 0 {
+			fmt.Println("ℹ️ No matching entries.")
+			return
+		}
+		fmt.Printf("\n🕒 %d entries, %.2f hrs\n", count, total)
+	},
+}
+
+// User sheet layout: rows 1-2 hold the bucket list and headers, entries are
+// read from row 5 onwards with columns
+//
+//	A date | B day | C project | D task_description | E hours | F timestamp | G invoiced | H tags | I ticket | J timer
+const entriesRange = "!A5:J"
+
+type sheetEntry struct {
+	Row       int
+	Date      time.Time
+	Day       string
+	Bucket    string
+	Task      string
+	Hours     float64
+	Timestamp string
+	Invoiced  string
+	Tags      []string
+	Ticket    string
+	Timer     string
+}
+
+func fetchEntries(client store.Client, userSheet string) ([]sheetEntry, error) {
+	resp, err := client.Get(userSheet + entriesRange)
+	if err != nil {
+		return nil, err
+	}
+	return parseEntries(resp), nil
+}
+
+// parseEntries reads the rows of entriesRange into entries.
+func parseEntries(resp [][]interface{}) []sheetEntry {
+	var entries []sheetEntry
+	for i, row := range resp {
+		if len(row) < 5 {
+			continue
+		}
+
+		date, err := time.Parse("02/01/06", cellString(row, 0))
+		if err != nil {
+			continue
+		}
+
+		var hrs float64
+		fmt.Sscanf(cellString(row, 4), "%f", &hrs)
+
+		entries = append(entries, sheetEntry{
+			Row:       i + 5,
+			Date:      date,
+			Day:       cellString(row, 1),
+			Bucket:    cellString(row, 2),
+			Task:      cellString(row, 3),
+			Hours:     hrs,
+			Timestamp: cellString(row, 5),
+			Invoiced:  cellString(row, 6),
+			Tags:      internal.SplitTags(cellString(row, 7)),
+			Ticket:    cellString(row, 8),
+			Timer:     cellString(row, 9),
+		})
+	}
+	return entries
+}
+
+func (e sheetEntry) hasAnyTag(tags []string) bool {
+	for _, want := range tags {
+		for _, t := range e.Tags {
+			if t == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// detectTicket finds the ticket in a task description, limited to the
+// project keys set with 'timesheet config set ticket-prefixes'.
+func detectTicket(desc string) string {
+	var prefixes []string
+	if cfg, err := internal.LoadConfig(); err == nil {
+		prefixes = cfg.TicketPrefixes
+	}
+	return internal.DetectTicket(desc, prefixes)
+}
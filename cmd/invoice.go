@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/invoice"
+)
+
+var (
+	invoiceClient   string
+	invoiceMonth    string
+	invoiceFormat   string
+	invoiceTemplate string
+	invoiceOut      string
+	invoiceMark     bool
+)
+
+var invoiceCmd = &cobra.Command{
+	Use:   "invoice",
+	Short: "🧾 Generate an invoice from billable hours",
+	Long: `Generate an invoice for a client from this month's billable entries.
+
+Entries are grouped by bucket and priced with the bucket's rate (see
+'timesheet bucket set'). Templates can be customized by placing
+invoice.md.tmpl or invoice.html.tmpl in ~/.timesheet/templates, or with
+--template. Use --mark to stamp the invoice number on the billed entries
+so they are skipped next time.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		if invoiceClient == "" {
+			fmt.Println("  Please provide --client.")
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		format := strings.ToLower(invoiceFormat)
+		if format == "markdown" {
+			format = "md"
+		}
+
+		period := time.Now()
+		if invoiceMonth != "" {
+			parsed, err := time.Parse("2006-01", invoiceMonth)
+			if err != nil {
+				log.Fatalf("  Invalid month format. Use yyyy-mm")
+			}
+			period = parsed
+		}
+		periodStart := time.Date(period.Year(), period.Month(), 1, 0, 0, 0, 0, time.UTC)
+		periodEnd := periodStart.AddDate(0, 1, 0)
+
+		client := getSheetsClient()
+		userSheet := internal.CurrentUserID
+
+		settings, err := loadBucketSettings(client)
+		if err != nil {
+			log.Fatalf("  Failed to read bucket settings: %v", err)
+		}
+		overrides, err := loadRateOverrides(client)
+		if err != nil {
+			log.Fatalf("  Failed to read rate overrides: %v", err)
+		}
+
+		entries, err := fetchEntries(client, userSheet)
+		if err != nil {
+			log.Fatalf("  Failed to fetch timesheet data: %v", err)
+		}
+
+		release, err := invoice.LockSequence(internal.ConfigPath())
+		if err != nil {
+			log.Fatalf("  Failed to lock invoice numbers: %v", err)
+		}
+		defer release()
+		number, err := invoice.PeekNumber(internal.ConfigPath())
+		if err != nil {
+			log.Fatalf("  %v", err)
+		}
+
+		inv := &invoice.Invoice{
+			Number:    number,
+			Client:    invoiceClient,
+			Employee:  userSheet,
+			Period:    periodStart,
+			IssueDate: time.Now(),
+		}
+
+		lines := map[string]*invoice.Line{}
+		var billedRows []int
+		for _, e := range entries {
+			if e.Date.Before(periodStart) || !e.Date.Before(periodEnd) || e.Invoiced != "" {
+				continue
+			}
+			setting, ok := settings[e.Bucket]
+			if !ok || !setting.Billable || !strings.EqualFold(setting.Client, invoiceClient) {
+				continue
+			}
+			if inv.Currency == "" {
+				inv.Currency = setting.Currency
+			} else if inv.Currency != setting.Currency {
+				log.Fatalf("  Buckets for %s use more than one currency (%s, %s)", invoiceClient, inv.Currency, setting.Currency)
+			}
+
+			line, ok := lines[e.Bucket]
+			if !ok {
+				line = &invoice.Line{Bucket: e.Bucket, Rate: rateFor(setting, overrides, userSheet)}
+				lines[e.Bucket] = line
+			}
+			line.Hours += e.Hours
+			line.Items = append(line.Items, invoice.Item{Date: e.Date, Task: e.Task, Hours: e.Hours})
+			billedRows = append(billedRows, e.Row)
+		}
+
+		if len(lines) == 0 {
+			fmt.Printf("ℹ️ No uninvoiced billable entries for %s in %s.\n", invoiceClient, periodStart.Format("January 2006"))
+			return
+		}
+
+		for _, line := range lines {
+			line.Amount = line.Hours * line.Rate
+			inv.Hours += line.Hours
+			inv.Total += line.Amount
+			inv.Lines = append(inv.Lines, *line)
+		}
+		sort.Slice(inv.Lines, func(i, j int) bool { return inv.Lines[i].Bucket < inv.Lines[j].Bucket })
+
+		data, err := invoice.Render(inv, format, invoiceTemplate, internal.ConfigPath())
+		if err != nil {
+			log.Fatalf("  Failed to render invoice: %v", err)
+		}
+
+		out := invoiceOut
+		if out == "" {
+			out = number + "." + format
+		}
+		if err := os.WriteFile(out, data, 0644); err != nil {
+			log.Fatalf("  Failed to write invoice: %v", err)
+		}
+		if err := invoice.CommitNumber(internal.ConfigPath()); err != nil {
+			log.Fatalf("  Failed to record invoice number: %v", err)
+		}
+		release()
+
+		if invoiceMark {
+			var updates []*sheets.ValueRange
+			for _, row := range billedRows {
+				updates = append(updates, &sheets.ValueRange{
+					Range:  fmt.Sprintf("%s!G%d", userSheet, row),
+					Values: [][]interface{}{{number}},
+				})
+			}
+			err = client.BatchUpdate(updates, "RAW")
+			if err != nil {
+				log.Fatalf("  Invoice written but failed to mark entries: %v", err)
+			}
+			fmt.Printf("🔖 Marked %d entries as invoiced on %s\n", len(billedRows), number)
+		}
+
+		fmt.Printf("🧾 Invoice %s for %s: %.2f hrs, %.2f %s → %s\n", number, invoiceClient, inv.Hours, inv.Total, inv.Currency, out)
+	},
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Leave is logged in the user's own sheet as rows in leaveBucket with the
+// leave type as task. Public holidays are shared in the "calendar" tab:
+//
+//	calendar: date (dd/mm/yy) | name | emp_id
+//
+// A holiday with an empty emp_id applies to everyone.
+const (
+	leaveBucket   = "leave"
+	calendarSheet = "calendar"
+)
+
+var (
+	leaveType     string
+	leaveHours    float64
+	leaveAll      bool
+	leavePersonal bool
+)
+
+var leaveCmd = &cobra.Command{
+	Use:   "leave",
+	Short: "🏖️ Record leave and import public holidays",
+	Long: `Record leave and public holidays.
+
+Leave and holidays lower the expected hours in 'report', and 'template
+apply' skips those days.`,
+}
+
+var leaveAddCmd = &cobra.Command{
+	Use:   "add <date> [date...]",
+	Short: "Record leave on one or more days (yyyy-mm-dd or dd/mm/yy)",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		hours := leaveHours
+		if hours <= 0 {
+			hours = cfg.DailyHours()
+		}
+		kind := strings.ToLower(strings.TrimSpace(leaveType))
+
+		client := getSheetsClient()
+		userSheet := internal.CurrentUserID
+		entries, err := fetchEntries(client, userSheet)
+		if err != nil {
+			log.Fatalf("  Failed to read timesheet rows: %v", err)
+		}
+
+		loc := cfg.Location()
+		var rows [][]interface{}
+		var days []time.Time
+		for _, arg := range args {
+			day, err := parseDay(arg, loc)
+			if err != nil {
+				log.Fatalf("  %v", err)
+			}
+			date := day.Format("02/01/06")
+			if onLeave(entries, date) {
+				fmt.Printf("⏭️  %s %s already has leave recorded.\n", day.Format("Mon"), date)
+				continue
+			}
+			rows = append(rows, []interface{}{
+				date, day.Format("Monday"), leaveBucket, kind, fmt.Sprintf("%.2f", hours),
+				day.Format(time.RFC3339), "", internal.JoinTags([]string{kind}), "",
+			})
+			days = append(days, day)
+			fmt.Printf("🏖️  %s %s: %.2f hrs %s\n", day.Format("Mon"), date, hours, kind)
+		}
+
+		if len(rows) == 0 {
+			return
+		}
+		ensureWeeksOpen(client, userSheet, days...)
+		if err := client.Append(userSheet+"!A3:J", rows, "USER_ENTERED"); err != nil {
+			log.Fatalf("  Failed to record leave: %v", err)
+		}
+		fmt.Printf("   Recorded %d leave day(s).\n", len(rows))
+	},
+}
+
+var leaveListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List leave and holidays of this year",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		userSheet := internal.CurrentUserID
+		entries, err := fetchEntries(client, userSheet)
+		if err != nil {
+			log.Fatalf("  Failed to read timesheet rows: %v", err)
+		}
+		holidays, err := loadHolidays(client, userSheet)
+		if err != nil {
+			log.Printf("⚠️ Could not load holidays: %v", err)
+		}
+
+		year := time.Now().In(userLocation()).Year()
+		var lines []string
+		for _, e := range entries {
+			if e.Bucket == leaveBucket && (leaveAll || e.Date.Year() == year) {
+				lines = append(lines, fmt.Sprintf("%s  🏖️  %-10s %5.2f hrs", e.Date.Format("2006-01-02 Mon"), e.Task, e.Hours))
+			}
+		}
+		for date, name := range holidays {
+			day, _ := time.Parse("02/01/06", date)
+			if leaveAll || day.Year() == year {
+				lines = append(lines, fmt.Sprintf("%s  🎉 %s", day.Format("2006-01-02 Mon"), name))
+			}
+		}
+
+		if len(lines) == 0 {
+			fmt.Println("ℹ️ No leave or holidays recorded.")
+			return
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	},
+}
+
+var leaveImportCmd = &cobra.Command{
+	Use:   "import <file.ics>",
+	Short: "Import public holidays from an iCalendar file into the calendar tab",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatalf("  Failed to open calendar: %v", err)
+		}
+		defer f.Close()
+		holidays, err := internal.ParseICS(f)
+		if err != nil {
+			log.Fatalf("  Failed to read calendar: %v", err)
+		}
+
+		client := getSheetsClient()
+		if err := ensureCalendarSheet(client); err != nil {
+			log.Fatalf("  Failed to ensure calendar sheet: %v", err)
+		}
+
+		empID := ""
+		if leavePersonal {
+			empID = internal.CurrentUserID
+		}
+		existing, err := loadHolidays(client, empID)
+		if err != nil {
+			log.Fatalf("  Failed to read calendar sheet: %v", err)
+		}
+
+		var rows [][]interface{}
+		for _, h := range holidays {
+			date := h.Date.Format("02/01/06")
+			if _, ok := existing[date]; ok {
+				continue
+			}
+			existing[date] = h.Name
+			rows = append(rows, []interface{}{date, h.Name, empID})
+		}
+
+		if len(rows) == 0 {
+			fmt.Println("ℹ️ All holidays are already in the calendar.")
+			return
+		}
+		if err := client.Append(calendarSheet+"!A:C", rows, "RAW"); err != nil {
+			log.Fatalf("  Failed to import holidays: %v", err)
+		}
+		fmt.Printf("🎉 Imported %d holiday(s).\n", len(rows))
+	},
+}
+
+func ensureCalendarSheet(client store.Client) error {
+	return ensureTab(client, calendarSheet, []interface{}{"date", "name", "emp_id"})
+}
+
+// loadHolidays returns the holidays that apply to empID by date (dd/mm/yy).
+// A missing calendar tab means no holidays.
+func loadHolidays(client store.Client, empID string) (map[string]string, error) {
+	holidays := map[string]string{}
+	found, err := tabExists(client, calendarSheet)
+	if err != nil || !found {
+		return holidays, err
+	}
+
+	resp, err := client.Get(calendarSheet + "!A2:C")
+	if err != nil {
+		return holidays, err
+	}
+	for _, row := range resp {
+		date := cellString(row, 0)
+		owner := cellString(row, 2)
+		if date == "" || (owner != "" && owner != empID) {
+			continue
+		}
+		holidays[date] = cellString(row, 1)
+	}
+	return holidays, nil
+}
+
+// parseDay reads a day given as yyyy-mm-dd or dd/mm/yy.
+func parseDay(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("02/01/06", s, loc); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s'. Use yyyy-mm-dd or dd/mm/yy", s)
+}
+
+func onLeave(entries []sheetEntry, date string) bool {
+	for _, e := range entries {
+		if e.Bucket == leaveBucket && e.Date.Format("02/01/06") == date {
+			return true
+		}
+	}
+	return false
+}
+
+// leaveHoursOn sums the leave logged on date.
+func leaveHoursOn(entries []sheetEntry, date string) float64 {
+	total := 0.0
+	for _, e := range entries {
+		if e.Bucket == leaveBucket && e.Date.Format("02/01/06") == date {
+			total += e.Hours
+		}
+	}
+	return total
+}
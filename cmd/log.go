@@ -4,13 +4,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
-	"google.golang.org/api/sheets/v4"
 
 	"github.com/srikanth-karthi/timesheet/internal"
-	"github.com/srikanth-karthi/timesheet/internal/setup"
 )
 
 var (
@@ -18,6 +17,8 @@ var (
 	logHours  string
 	logBucket string
 	logDate   string
+	logTags   []string
+	logTicket string
 )
 
 var logCmd = &cobra.Command{
@@ -29,30 +30,39 @@ var logCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		repoBucket, taskHint := workingDirContext()
+		if logTask == "" {
+			logTask = taskHint
+		}
+
 		if logTask == "" || logHours == "" {
 			fmt.Println("  Please provide both --task and --hours.")
 			cmd.Usage()
 			os.Exit(1)
 		}
 
-		provider := setup.GetCredentialProvider()
-		srv := setup.GetSheetsService(provider)
+		client := getSheetsClient()
 
 		userSheet := internal.CurrentUserID
-		meta, _ := internal.LoadMeta()
+		meta := loadMeta()
 
-		t := time.Now()
+		loc := userLocation()
+		t := time.Now().In(loc)
 		if logDate != "" {
-			parsed, err := time.Parse("02/01/06", logDate)
+			parsed, err := time.ParseInLocation("02/01/06", logDate, loc)
 			if err != nil {
 				log.Fatalf("  Invalid date format. Use dd/mm/yy")
 			}
 			t = parsed
 		}
+		ensureWeeksOpen(client, userSheet, t)
 		formattedDate := t.Format("02/01/06")
 		day := t.Format("Monday")
 
 		bucket := logBucket
+		if bucket == "" {
+			bucket = repoBucket
+		}
 		if bucket == "" {
 			bucket = meta.Active
 			if bucket == "" {
@@ -60,14 +70,14 @@ var logCmd = &cobra.Command{
 			}
 		}
 
-		bucketResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!C1:Z1").Do()
+		bucketResp, err := client.Get(userSheet + "!C1:Z1")
 		if err != nil {
 			log.Fatalf("  Could not fetch buckets: %v", err)
 		}
 
 		valid := false
-		if len(bucketResp.Values) > 0 {
-			for _, cell := range bucketResp.Values[0] {
+		if len(bucketResp) > 0 {
+			for _, cell := range bucketResp[0] {
 				if cellStr, ok := cell.(string); ok && cellStr == bucket {
 					valid = true
 					break
@@ -78,17 +88,27 @@ var logCmd = &cobra.Command{
 			log.Fatalf("  Bucket '%s' is not valid. Use 'timesheet bucket' to view available ones.", bucket)
 		}
 
+		tags := internal.NormalizeTags(append(logTags, internal.ParseTags(logTask)...))
+
+		ticket := internal.NormalizeTicket(logTicket)
+		if ticket == "" {
+			ticket = detectTicket(logTask)
+		}
+
 		timestamp := time.Now().Format(time.RFC3339)
-		_, err = srv.Spreadsheets.Values.Append(spreadsheetID, userSheet+"!A3:G", &sheets.ValueRange{
-			Values: [][]interface{}{
-				{formattedDate, day, bucket, logTask, logHours, timestamp},
-			},
-		}).ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Do()
+		err = client.Append(userSheet+"!A3:J", [][]interface{}{
+			{formattedDate, day, bucket, logTask, logHours, timestamp, "", internal.JoinTags(tags), ticket},
+		}, "USER_ENTERED")
 
+		if temporaryFailure(err) {
+			log.Fatalf("  Failed to log manual entry: %v\n   Check 'timesheet entries' before logging it again; it may have been written.", err)
+		}
 		if err != nil {
 			log.Fatalf("  Failed to log manual entry: %v", err)
 		}
 
 		fmt.Printf("   Logged task '%s' for %s hrs on %s [%s]\n", logTask, logHours, formattedDate, bucket)
+		hours, _ := strconv.ParseFloat(logHours, 64)
+		budgetWarnings(client, bucket, hours, t)
 	},
 }
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// recentTaskLimit is how many task descriptions the picker offers.
+const recentTaskLimit = 9
+
+// stdinIsTerminal reports whether stdin is an interactive terminal rather
+// than a pipe or file, so prompts that would block scripts can be skipped.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// latestEntry returns the work entry with the newest timestamp, or nil.
+// Leave rows are skipped: they are stamped on their (possibly future) day
+// and are nothing to continue.
+func latestEntry(entries []sheetEntry) *sheetEntry {
+	var latest *sheetEntry
+	var latestAt time.Time
+	for i, e := range entries {
+		if e.Bucket == leaveBucket {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		if latest == nil || at.After(latestAt) {
+			latest, latestAt = &entries[i], at
+		}
+	}
+	return latest
+}
+
+// recentTasks returns distinct task descriptions, most recently used first,
+// leaving out leave types. Timestamps are compared as instants since rows carry different offsets.
+func recentTasks(entries []sheetEntry, limit int) []string {
+	sorted := append([]sheetEntry(nil), entries...)
+	at := func(e sheetEntry) time.Time {
+		t, _ := time.Parse(time.RFC3339, e.Timestamp)
+		return t
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return at(sorted[i]).After(at(sorted[j])) })
+
+	seen := map[string]bool{}
+	var tasks []string
+	for _, e := range sorted {
+		key := strings.ToLower(e.Task)
+		if e.Task == "" || e.Bucket == leaveBucket || seen[key] {
+			continue
+		}
+		seen[key] = true
+		tasks = append(tasks, e.Task)
+		if len(tasks) == limit {
+			break
+		}
+	}
+	return tasks
+}
+
+// fuzzyMatch reports whether the runes of query appear in s in order,
+// ignoring case.
+func fuzzyMatch(query, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+utf8.RuneLen(r):]
+	}
+	return true
+}
+
+// readTaskDescription asks for a task description. On a terminal it offers
+// the recent tasks from the sheet: a number picks one, other input filters
+// the list until a single choice is left or the text is taken as typed.
+// From a pipe it reads one line. Empty input falls back to hint.
+func readTaskDescription(client store.Client, userSheet, hint string) string {
+	reader := bufio.NewReader(os.Stdin)
+	label := "📝 Task description"
+	if hint != "" {
+		label = fmt.Sprintf("📝 Task description [%s]", hint)
+	}
+
+	if !stdinIsTerminal() {
+		fmt.Print(label + ": ")
+		desc, _ := reader.ReadString('\n')
+		if desc = strings.TrimSpace(desc); desc == "" {
+			return hint
+		}
+		return desc
+	}
+
+	var recent []string
+	if entries, err := fetchEntries(client, userSheet); err == nil {
+		recent = recentTasks(entries, recentTaskLimit)
+	}
+
+	choices := recent
+	for {
+		if len(choices) > 0 {
+			fmt.Println("🕘 Recent tasks:")
+			for i, task := range choices {
+				fmt.Printf("  %d) %s\n", i+1, task)
+			}
+			fmt.Print(label + " (number or filter): ")
+		} else {
+			fmt.Print(label + ": ")
+		}
+
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return hint
+		}
+		if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(choices) {
+			return choices[n-1]
+		}
+
+		var matches []string
+		for _, task := range choices {
+			if strings.EqualFold(task, input) {
+				return task
+			}
+			if fuzzyMatch(input, task) {
+				matches = append(matches, task)
+			}
+		}
+		if len(matches) == 0 || len(matches) == len(choices) {
+			return input
+		}
+
+		fmt.Printf("🔎 %d matching task(s). Pick a number, or press Enter to use '%s' as typed.\n", len(matches), input)
+		for i, task := range matches {
+			fmt.Printf("  %d) %s\n", i+1, task)
+		}
+		fmt.Print("Choice: ")
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return input
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(matches) {
+			return matches[n-1]
+		}
+		choices = matches
+	}
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Published tabs are rebuilt from scratch on every run:
+//
+//	summary-<emp_id>: week | <bucket>... | total | target | diff
+//	dashboard:        week | <emp_id>... | <emp_id> target...
+//
+// Managers get "dashboard-<emp_id>" for their reports; admins the shared
+// "dashboard" for everyone.
+const (
+	summaryPrefix  = "summary-"
+	dashboardSheet = "dashboard"
+)
+
+var publishWeeks int
+
+var (
+	underTargetColor = &sheets.Color{Red: 0.96, Green: 0.80, Blue: 0.80}
+	overTargetColor  = &sheets.Color{Red: 0.85, Green: 0.92, Blue: 0.83}
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "📈 Write weekly summary and dashboard tabs with charts into the spreadsheet",
+	Long: `Create or refresh summary tabs with weekly totals per bucket, target and
+difference, plus a chart and highlighting of weeks under or over target.
+
+Employees publish their own summary. Managers also publish their direct
+reports' summaries and a team dashboard; admins do so for everyone.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+		if publishWeeks < 1 {
+			log.Fatalf("  --weeks must be at least 1")
+		}
+
+		client := getSheetsClient()
+		me := internal.CurrentUserID
+		users, err := loadAdminUsers(client)
+		if err != nil {
+			log.Fatalf("  Failed to read users: %v", err)
+		}
+		role, err := userRole(client, me)
+		if err != nil {
+			log.Fatalf("  Failed to read roles: %v", err)
+		}
+
+		people := []string{me}
+		dashboard := ""
+		switch role {
+		case roleAdmin:
+			dashboard = dashboardSheet
+			people = nil
+			for _, u := range users {
+				if u.Status == userActive {
+					people = append(people, u.EmpID)
+				}
+			}
+		case roleManager:
+			dashboard = dashboardSheet + "-" + me
+			people = append(people, directReports(users, me)...)
+		}
+		sort.Strings(people)
+
+		all, err := fetchUsersEntries(client, people)
+		if err != nil {
+			log.Fatalf("  Failed to fetch timesheets: %v", err)
+		}
+
+		now := time.Now().In(userLocation())
+		today := dateOf(now)
+		var weeks []time.Time
+		first := weekDays(today)[0].AddDate(0, 0, -7*(publishWeeks-1))
+		for w := first; !w.After(today); w = w.AddDate(0, 0, 7) {
+			weeks = append(weeks, w)
+		}
+
+		totals := map[string][]float64{}
+		targets := map[string][]float64{}
+		for _, person := range people {
+			entries, ok := all[person]
+			if !ok {
+				continue
+			}
+			cfg, _ := loadContract(client, person)
+			holidays, err := loadHolidays(client, person)
+			if err != nil {
+				log.Printf("⚠️ Could not load holidays: %v", err)
+			}
+			weekTotals, weekTargets, err := publishSummary(client, person, entries, weeks, today, cfg, holidays)
+			if err != nil {
+				log.Printf("⚠️ Failed to publish summary for %s: %v", person, err)
+				continue
+			}
+			totals[person], targets[person] = weekTotals, weekTargets
+			fmt.Printf("📄 Published %s%s\n", summaryPrefix, person)
+		}
+
+		if dashboard != "" {
+			if err := publishDashboard(client, dashboard, people, weeks, totals, targets); err != nil {
+				log.Fatalf("  Failed to publish dashboard: %v", err)
+			}
+			fmt.Printf("📊 Published %s\n", dashboard)
+		}
+	},
+}
+
+// publishSummary rebuilds the summary tab of one user and returns their
+// weekly totals and targets.
+func publishSummary(client store.Client, empID string, entries []sheetEntry, weeks []time.Time, today time.Time, cfg *internal.Config, holidays map[string]string) ([]float64, []float64, error) {
+	bucketSet := map[string]bool{}
+	perWeek := make([]map[string]float64, len(weeks))
+	for i := range perWeek {
+		perWeek[i] = map[string]float64{}
+	}
+	for _, e := range entries {
+		for i, w := range weeks {
+			if !e.Date.Before(w) && e.Date.Before(w.AddDate(0, 0, 7)) {
+				perWeek[i][e.Bucket] += e.Hours
+				bucketSet[e.Bucket] = true
+			}
+		}
+	}
+	buckets := sortedKeys(bucketSet)
+
+	header := []interface{}{"week"}
+	for _, b := range buckets {
+		header = append(header, b)
+	}
+	header = append(header, "total", "target", "diff")
+	rows := [][]interface{}{header}
+
+	totals := make([]float64, len(weeks))
+	targets := make([]float64, len(weeks))
+	for i, w := range weeks {
+		row := []interface{}{w.Format("2006-01-02")}
+		for _, b := range buckets {
+			row = append(row, round2(perWeek[i][b]))
+			if b != leaveBucket {
+				totals[i] += perWeek[i][b]
+			}
+		}
+		to := w.AddDate(0, 0, 6)
+		if to.After(today) {
+			to = today
+		}
+		targets[i] = measurePeriod(cfg, entries, holidays, w, to).Target
+		row = append(row, round2(totals[i]), round2(targets[i]), round2(totals[i]-targets[i]))
+		rows = append(rows, row)
+	}
+
+	title := summaryPrefix + empID
+	sheetID, err := recreateTab(client, title)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.Update(title+"!A1", rows, "USER_ENTERED"); err != nil {
+		return nil, nil, err
+	}
+
+	n := int64(len(weeks))
+	diffCol := int64(len(header) - 1)
+	var requests []*sheets.Request
+	// A chart without series is rejected, so weeks without entries get none.
+	if len(buckets) > 0 {
+		requests = append(requests, addChart(sheetID, "Hours per week by bucket", "COLUMN", "STACKED", n, 1, int64(len(buckets)), diffCol+2))
+	}
+	requests = append(requests, targetHighlights(&sheets.GridRange{
+		SheetId: sheetID, StartRowIndex: 1, EndRowIndex: n + 1, StartColumnIndex: diffCol, EndColumnIndex: diffCol + 1,
+	}, "NUMBER_LESS", "NUMBER_GREATER", "0")...)
+
+	_, err = client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{Requests: requests})
+	return totals, targets, err
+}
+
+// publishDashboard rebuilds a team tab with each person's weekly totals
+// next to their target for the same week.
+func publishDashboard(client store.Client, title string, people []string, weeks []time.Time, totals, targets map[string][]float64) error {
+	var shown []string
+	for _, p := range people {
+		if _, ok := totals[p]; ok {
+			shown = append(shown, p)
+		}
+	}
+
+	header := []interface{}{"week"}
+	for _, p := range shown {
+		header = append(header, p)
+	}
+	for _, p := range shown {
+		header = append(header, p+" target")
+	}
+	rows := [][]interface{}{header}
+	for i, w := range weeks {
+		row := []interface{}{w.Format("2006-01-02")}
+		for _, p := range shown {
+			row = append(row, round2(totals[p][i]))
+		}
+		for _, p := range shown {
+			row = append(row, round2(targets[p][i]))
+		}
+		rows = append(rows, row)
+	}
+
+	sheetID, err := recreateTab(client, title)
+	if err != nil {
+		return err
+	}
+	if err := client.Update(title+"!A1", rows, "USER_ENTERED"); err != nil {
+		return err
+	}
+	if len(shown) == 0 {
+		return nil
+	}
+
+	n := int64(len(weeks))
+	cols := int64(len(shown))
+	// Compare every week with the same week's target, cols columns right.
+	requests := []*sheets.Request{
+		addChart(sheetID, "Weekly hours per person", "LINE", "", n, 1, cols, 2*cols+2),
+	}
+	requests = append(requests, targetHighlights(&sheets.GridRange{
+		SheetId: sheetID, StartRowIndex: 1, EndRowIndex: n + 1, StartColumnIndex: 1, EndColumnIndex: cols + 1,
+	}, "CUSTOM_FORMULA", "CUSTOM_FORMULA", fmt.Sprint(cols))...)
+
+	_, err = client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{Requests: requests})
+	return err
+}
+
+// recreateTab deletes the tab if it exists and adds it again with a frozen
+// header row, returning its sheet id.
+func recreateTab(client store.Client, title string) (int64, error) {
+	ss, err := client.Spreadsheet()
+	if err != nil {
+		return 0, err
+	}
+
+	var requests []*sheets.Request
+	for _, sheet := range ss.Sheets {
+		if sheet.Properties.Title == title {
+			requests = append(requests, &sheets.Request{
+				DeleteSheet: &sheets.DeleteSheetRequest{SheetId: sheet.Properties.SheetId},
+			})
+		}
+	}
+	requests = append(requests, &sheets.Request{
+		AddSheet: &sheets.AddSheetRequest{
+			Properties: &sheets.SheetProperties{
+				Title:          title,
+				GridProperties: &sheets.GridProperties{FrozenRowCount: 1},
+			},
+		},
+	})
+
+	resp, err := client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{Requests: requests})
+	if err != nil {
+		return 0, err
+	}
+	for _, reply := range resp.Replies {
+		if reply.AddSheet != nil {
+			return reply.AddSheet.Properties.SheetId, nil
+		}
+	}
+	return 0, fmt.Errorf("no sheet id returned for '%s'", title)
+}
+
+// addChart builds a chart over rows 0..n (header plus n data rows) with
+// column 0 as domain and columns first..first+count-1 as series, anchored
+// at column anchorCol.
+func addChart(sheetID int64, title, chartType, stacked string, n, first, count, anchorCol int64) *sheets.Request {
+	column := func(c int64) *sheets.ChartData {
+		return &sheets.ChartData{SourceRange: &sheets.ChartSourceRange{Sources: []*sheets.GridRange{{
+			SheetId: sheetID, StartRowIndex: 0, EndRowIndex: n + 1, StartColumnIndex: c, EndColumnIndex: c + 1,
+		}}}}
+	}
+
+	var series []*sheets.BasicChartSeries
+	for c := first; c < first+count; c++ {
+		series = append(series, &sheets.BasicChartSeries{Series: column(c), TargetAxis: "LEFT_AXIS"})
+	}
+
+	return &sheets.Request{
+		AddChart: &sheets.AddChartRequest{
+			Chart: &sheets.EmbeddedChart{
+				Spec: &sheets.ChartSpec{
+					Title: title,
+					BasicChart: &sheets.BasicChartSpec{
+						ChartType:      chartType,
+						StackedType:    stacked,
+						LegendPosition: "RIGHT_LEGEND",
+						HeaderCount:    1,
+						Axis: []*sheets.BasicChartAxis{
+							{Position: "BOTTOM_AXIS", Title: "Week"},
+							{Position: "LEFT_AXIS", Title: "Hours"},
+						},
+						Domains: []*sheets.BasicChartDomain{{Domain: column(0)}},
+						Series:  series,
+					},
+				},
+				Position: &sheets.EmbeddedObjectPosition{
+					OverlayPosition: &sheets.OverlayPosition{
+						AnchorCell: &sheets.GridCoordinate{SheetId: sheetID, RowIndex: 0, ColumnIndex: anchorCol},
+					},
+				},
+			},
+		},
+	}
+}
+
+// targetHighlights colours cells under target red and over target green.
+// Number conditions compare the cells with value; with CUSTOM_FORMULA value
+// is how many columns right of each cell its target is.
+func targetHighlights(rng *sheets.GridRange, under, over, value string) []*sheets.Request {
+	underValue, overValue := value, value
+	if under == "CUSTOM_FORMULA" {
+		offset, _ := strconv.Atoi(value)
+		row := fmt.Sprint(rng.StartRowIndex + 1)
+		cell := columnNumberToLetter(int(rng.StartColumnIndex)+1) + row
+		target := columnNumberToLetter(int(rng.StartColumnIndex)+1+offset) + row
+		underValue = "=" + cell + "<" + target
+		overValue = "=" + cell + ">" + target
+	}
+
+	rule := func(kind, v string, color *sheets.Color) *sheets.Request {
+		return &sheets.Request{
+			AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+				Rule: &sheets.ConditionalFormatRule{
+					Ranges: []*sheets.GridRange{rng},
+					BooleanRule: &sheets.BooleanRule{
+						Condition: &sheets.BooleanCondition{
+							Type:   kind,
+							Values: []*sheets.ConditionValue{{UserEnteredValue: v}},
+						},
+						Format: &sheets.CellFormat{BackgroundColor: color},
+					},
+				},
+			},
+		}
+	}
+	return []*sheets.Request{
+		rule(under, underValue, underTargetColor),
+		rule(over, overValue, overTargetColor),
+	}
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
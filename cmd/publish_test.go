@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// sourceColumns returns the columns a chart series or domain reads.
+func sourceColumns(data *sheets.ChartData) (start, end int64) {
+	rng := data.SourceRange.Sources[0]
+	return rng.StartColumnIndex, rng.EndColumnIndex
+}
+
+func TestAddChart(t *testing.T) {
+	req := addChart(7, "Hours", "COLUMN", "STACKED", 4, 1, 3, 6)
+
+	chart := req.AddChart.Chart
+	spec := chart.Spec.BasicChart
+	if spec.ChartType != "COLUMN" || spec.StackedType != "STACKED" || spec.HeaderCount != 1 {
+		t.Errorf("spec = %+v", spec)
+	}
+	if start, end := sourceColumns(spec.Domains[0].Domain); start != 0 || end != 1 {
+		t.Errorf("domain columns %d..%d, want 0..1", start, end)
+	}
+	if len(spec.Series) != 3 {
+		t.Fatalf("%d series, want 3", len(spec.Series))
+	}
+	for i, s := range spec.Series {
+		start, end := sourceColumns(s.Series)
+		if start != int64(i+1) || end != int64(i+2) {
+			t.Errorf("series %d reads columns %d..%d", i, start, end)
+		}
+		// Header plus four weeks.
+		if rng := s.Series.SourceRange.Sources[0]; rng.SheetId != 7 || rng.StartRowIndex != 0 || rng.EndRowIndex != 5 {
+			t.Errorf("series %d reads %+v", i, rng)
+		}
+	}
+	if anchor := chart.Position.OverlayPosition.AnchorCell; anchor.SheetId != 7 || anchor.ColumnIndex != 6 {
+		t.Errorf("anchor = %+v", anchor)
+	}
+}
+
+func conditionOf(req *sheets.Request) (kind, value string, color *sheets.Color) {
+	rule := req.AddConditionalFormatRule.Rule.BooleanRule
+	return rule.Condition.Type, rule.Condition.Values[0].UserEnteredValue, rule.Format.BackgroundColor
+}
+
+func TestTargetHighlights(t *testing.T) {
+	rng := &sheets.GridRange{SheetId: 3, StartRowIndex: 1, EndRowIndex: 5, StartColumnIndex: 1, EndColumnIndex: 4}
+
+	tests := []struct {
+		name             string
+		under, over      string
+		value            string
+		wantUnder, wantO string
+	}{
+		{"numbers", "NUMBER_LESS", "NUMBER_GREATER", "0", "0", "0"},
+		// Each cell is compared with the cell three columns right.
+		{"formula", "CUSTOM_FORMULA", "CUSTOM_FORMULA", "3", "=B2<E2", "=B2>E2"},
+	}
+	for _, tt := range tests {
+		reqs := targetHighlights(rng, tt.under, tt.over, tt.value)
+		if len(reqs) != 2 {
+			t.Fatalf("%s: %d requests, want 2", tt.name, len(reqs))
+		}
+		if kind, value, color := conditionOf(reqs[0]); kind != tt.under || value != tt.wantUnder || color != underTargetColor {
+			t.Errorf("%s: under rule = %s %q %v", tt.name, kind, value, color)
+		}
+		if kind, value, color := conditionOf(reqs[1]); kind != tt.over || value != tt.wantO || color != overTargetColor {
+			t.Errorf("%s: over rule = %s %q %v", tt.name, kind, value, color)
+		}
+		if got := reqs[0].AddConditionalFormatRule.Rule.Ranges[0]; got != rng {
+			t.Errorf("%s: rule range = %+v", tt.name, got)
+		}
+	}
+}
+
+func TestPublishSummary(t *testing.T) {
+	client := newFakeSheets()
+	weeks := []time.Time{day(5), day(12)}
+	entries := append(workWeek(8), sheetEntry{Date: day(16), Bucket: leaveBucket, Hours: 8}, sheetEntry{Date: day(13), Bucket: "ops", Hours: 2})
+
+	totals, targets, err := publishSummary(client, "E1", entries, weeks, day(18), &internal.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Leave is shown in its own column but not counted as worked.
+	if want := []float64{0, 42}; !reflect.DeepEqual(totals, want) {
+		t.Errorf("totals = %v, want %v", totals, want)
+	}
+	if want := []float64{40, 32}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("targets = %v, want %v", targets, want)
+	}
+
+	tab := client.tabs[summaryPrefix+"E1"]
+	wantRows := [][]interface{}{
+		{"week", "general", leaveBucket, "ops", "total", "target", "diff"},
+		{"2026-10-05", 0.0, 0.0, 0.0, 0.0, 40.0, -40.0},
+		{"2026-10-12", 40.0, 8.0, 2.0, 42.0, 32.0, 10.0},
+	}
+	if !reflect.DeepEqual(tab, wantRows) {
+		t.Errorf("summary tab =\n%v\nwant\n%v", tab, wantRows)
+	}
+
+	last := client.requests[len(client.requests)-3:]
+	if last[0].AddChart == nil || len(last[0].AddChart.Chart.Spec.BasicChart.Series) != 3 {
+		t.Errorf("want a chart with a series per bucket, got %+v", last[0])
+	}
+	// The diff column is highlighted.
+	if rng := last[1].AddConditionalFormatRule.Rule.Ranges[0]; rng.StartColumnIndex != 6 || rng.EndRowIndex != 3 {
+		t.Errorf("highlighted range = %+v", rng)
+	}
+}
+
+func TestPublishSummaryWithoutEntries(t *testing.T) {
+	client := newFakeSheets()
+	if _, _, err := publishSummary(client, "E1", nil, []time.Time{day(12)}, day(18), &internal.Config{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range client.requests {
+		if req.AddChart != nil {
+			t.Error("a chart without series was requested")
+		}
+	}
+}
+
+func TestPublishDashboard(t *testing.T) {
+	client := newFakeSheets()
+	weeks := []time.Time{day(5), day(12)}
+	totals := map[string][]float64{"E1": {40, 38.5}, "E3": {12, 20}}
+	targets := map[string][]float64{"E1": {40, 40}, "E3": {16, 16}}
+
+	// E2 has no summary and is left out.
+	if err := publishDashboard(client, "dashboard", []string{"E1", "E2", "E3"}, weeks, totals, targets); err != nil {
+		t.Fatal(err)
+	}
+
+	wantRows := [][]interface{}{
+		{"week", "E1", "E3", "E1 target", "E3 target"},
+		{"2026-10-05", 40.0, 12.0, 40.0, 16.0},
+		{"2026-10-12", 38.5, 20.0, 40.0, 16.0},
+	}
+	if tab := client.tabs["dashboard"]; !reflect.DeepEqual(tab, wantRows) {
+		t.Errorf("dashboard tab =\n%v\nwant\n%v", tab, wantRows)
+	}
+
+	last := client.requests[len(client.requests)-3:]
+	if series := last[0].AddChart.Chart.Spec.BasicChart.Series; len(series) != 2 {
+		t.Errorf("%d series, want one per person", len(series))
+	}
+	if _, value, _ := conditionOf(last[1]); value != "=B2<D2" {
+		t.Errorf("under target formula = %q, want =B2<D2", value)
+	}
+}
+
+func TestRecreateTab(t *testing.T) {
+	client := newFakeSheets()
+	first, err := recreateTab(client, "dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.tabs["dashboard"] = [][]interface{}{{"stale"}}
+
+	second, err := recreateTab(client, "dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second == first {
+		t.Errorf("sheet id %d reused", second)
+	}
+	if len(client.tabs["dashboard"]) != 0 {
+		t.Errorf("old rows kept: %v", client.tabs["dashboard"])
+	}
+	deleted := client.requests[len(client.requests)-2].DeleteSheet
+	if deleted == nil || deleted.SheetId != first {
+		t.Errorf("old tab not deleted: %+v", client.requests[len(client.requests)-2])
+	}
+}
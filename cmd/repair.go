@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// headerProtection marks the protected range put on the bucket and header
+// rows, so repair-sheet can replace it instead of stacking duplicates.
+const headerProtection = "timesheet: bucket list and headers"
+
+var repairAll bool
+
+var repairSheetCmd = &cobra.Command{
+	Use:   "repair-sheet [emp_id...]",
+	Short: "🧰 Reapply formatting, validation and protection to user sheets",
+	Long: `Reapply the layout 'setup' gives new user sheets to existing ones: frozen
+header rows, a number format on the hours column, validation restricting
+the project column to the bucket list (and leave) and protection of the
+bucket and header rows.
+
+Without arguments your own sheet is repaired. Repairing other users' sheets
+or --all needs the admin role.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		targets := args
+		if repairAll {
+			requireRole(client, internal.CurrentUserID, roleAdmin)
+			users, err := listUsers(client)
+			if err != nil {
+				log.Fatalf("  Failed to read users: %v", err)
+			}
+			targets = users
+		} else if len(targets) == 0 {
+			targets = []string{internal.CurrentUserID}
+		} else if len(targets) > 1 || targets[0] != internal.CurrentUserID {
+			requireRole(client, internal.CurrentUserID, roleAdmin)
+		}
+
+		ss, err := client.Spreadsheet()
+		if err != nil {
+			log.Fatalf("  Failed to read spreadsheet: %v", err)
+		}
+		sheetsByTitle := map[string]*sheets.Sheet{}
+		for _, sheet := range ss.Sheets {
+			sheetsByTitle[sheet.Properties.Title] = sheet
+		}
+
+		for _, empID := range targets {
+			sheet, ok := sheetsByTitle[empID]
+			if !ok {
+				fmt.Printf("⚠️ No sheet for %s, skipped.\n", empID)
+				continue
+			}
+			if err := formatUserSheet(client, sheet.Properties.SheetId, sheet.ProtectedRanges); err != nil {
+				log.Fatalf("  Failed to repair sheet of %s: %v", empID, err)
+			}
+			fmt.Printf("🧰 Repaired sheet '%s'\n", empID)
+		}
+	},
+}
+
+// formatUserSheet freezes the two header rows, formats the hours column,
+// restricts the project column to the bucket list in row 1 or leave and
+// protects rows 1-2. Protections from an earlier run in existing are
+// replaced.
+func formatUserSheet(client store.Client, sheetID int64, existing []*sheets.ProtectedRange) error {
+	var requests []*sheets.Request
+	for _, p := range existing {
+		if p.Description == headerProtection {
+			requests = append(requests, &sheets.Request{
+				DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{ProtectedRangeId: p.ProtectedRangeId},
+			})
+		}
+	}
+
+	entryRows := func(col int64) *sheets.GridRange {
+		return &sheets.GridRange{SheetId: sheetID, StartRowIndex: 2, StartColumnIndex: col, EndColumnIndex: col + 1}
+	}
+
+	requests = append(requests,
+		&sheets.Request{
+			UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+				Properties: &sheets.SheetProperties{
+					SheetId:        sheetID,
+					GridProperties: &sheets.GridProperties{FrozenRowCount: 2},
+				},
+				Fields: "gridProperties.frozenRowCount",
+			},
+		},
+		&sheets.Request{
+			RepeatCell: &sheets.RepeatCellRequest{
+				Range: entryRows(4),
+				Cell: &sheets.CellData{
+					UserEnteredFormat: &sheets.CellFormat{
+						NumberFormat: &sheets.NumberFormat{Type: "NUMBER", Pattern: "0.00"},
+					},
+				},
+				Fields: "userEnteredFormat.numberFormat",
+			},
+		},
+		&sheets.Request{
+			SetDataValidation: &sheets.SetDataValidationRequest{
+				Range: entryRows(2),
+				Rule: &sheets.DataValidationRule{
+					// A bucket from row 1, or the leave rows 'leave' writes.
+					Condition: &sheets.BooleanCondition{
+						Type:   "CUSTOM_FORMULA",
+						Values: []*sheets.ConditionValue{{UserEnteredValue: fmt.Sprintf(`=OR(C3="%s", COUNTIF($C$1:$Z$1, C3) > 0)`, leaveBucket)}},
+					},
+					InputMessage: fmt.Sprintf("Use a bucket from row 1 or '%s'", leaveBucket),
+					Strict:       true,
+				},
+			},
+		},
+		&sheets.Request{
+			AddProtectedRange: &sheets.AddProtectedRangeRequest{
+				ProtectedRange: &sheets.ProtectedRange{
+					Range:       &sheets.GridRange{SheetId: sheetID, StartRowIndex: 0, EndRowIndex: 2},
+					Description: headerProtection,
+				},
+			},
+		},
+	)
+
+	_, err := client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{Requests: requests})
+	return err
+}
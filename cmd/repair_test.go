@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestFormatUserSheet(t *testing.T) {
+	client := newFakeSheets()
+	existing := []*sheets.ProtectedRange{
+		{ProtectedRangeId: 11, Description: headerProtection},
+		// Protections someone added by hand are kept.
+		{ProtectedRangeId: 12, Description: "payroll"},
+	}
+	if err := formatUserSheet(client, 5, existing); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.calls) != 1 {
+		t.Fatalf("writes %v, want a single batch", client.calls)
+	}
+
+	var deleted []int64
+	var validation *sheets.SetDataValidationRequest
+	var protected []*sheets.ProtectedRange
+	var frozen, numbers bool
+	for _, req := range client.requests {
+		switch {
+		case req.DeleteProtectedRange != nil:
+			deleted = append(deleted, req.DeleteProtectedRange.ProtectedRangeId)
+		case req.SetDataValidation != nil:
+			validation = req.SetDataValidation
+		case req.AddProtectedRange != nil:
+			protected = append(protected, req.AddProtectedRange.ProtectedRange)
+		case req.UpdateSheetProperties != nil:
+			frozen = req.UpdateSheetProperties.Properties.GridProperties.FrozenRowCount == 2
+		case req.RepeatCell != nil:
+			numbers = req.RepeatCell.Range.StartColumnIndex == 4 && req.RepeatCell.Cell.UserEnteredFormat.NumberFormat.Pattern == "0.00"
+		}
+	}
+
+	if len(deleted) != 1 || deleted[0] != 11 {
+		t.Errorf("deleted protections %v, want [11]", deleted)
+	}
+	if len(protected) != 1 || protected[0].Description != headerProtection || protected[0].Range.EndRowIndex != 2 {
+		t.Errorf("protections added: %+v", protected)
+	}
+	if !frozen || !numbers {
+		t.Errorf("frozen header rows %v, hours format %v", frozen, numbers)
+	}
+
+	if validation == nil {
+		t.Fatal("no validation on the project column")
+	}
+	if rng := validation.Range; rng.SheetId != 5 || rng.StartColumnIndex != 2 || rng.StartRowIndex != 2 {
+		t.Errorf("validation range = %+v, want column C from row 3", rng)
+	}
+	// Leave rows are not in the bucket list and must still be accepted.
+	want := `=OR(C3="` + leaveBucket + `", COUNTIF($C$1:$Z$1, C3) > 0)`
+	if got := validation.Rule.Condition.Values[0].UserEnteredValue; got != want {
+		t.Errorf("validation formula = %s, want %s", got, want)
+	}
+	if !validation.Rule.Strict {
+		t.Error("validation is not strict")
+	}
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+var repoBucket string
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "📂 Map project folders to buckets",
+	Long: `Map project folders or git repositories to buckets.
+
+When 'start' or 'log' runs inside a mapped folder without --bucket, the
+mapped bucket is used and the task description is pre-filled from the
+current git branch or last commit subject.`,
+}
+
+var repoAddCmd = &cobra.Command{
+	Use:   "add [path]",
+	Short: "Map a folder (default: current repository) to a bucket",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if repoBucket == "" {
+			fmt.Println("  Please provide --bucket.")
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		path := "."
+		if len(args) == 1 {
+			path = args[0]
+		}
+		abs, err := resolveRepoPath(path)
+		if err != nil {
+			log.Fatalf("  Invalid path: %v", err)
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		cfg.MapRepo(abs, repoBucket)
+		if err := internal.SaveConfig(cfg); err != nil {
+			log.Fatalf("  Failed to save config: %v", err)
+		}
+		fmt.Printf("📂 Mapped %s → %s\n", abs, repoBucket)
+	},
+}
+
+var repoListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List folder to bucket mappings",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		if len(cfg.Repos) == 0 {
+			fmt.Println("ℹ️ No folders mapped. Use 'timesheet repo add --bucket <name>'.")
+			return
+		}
+		for _, r := range cfg.Repos {
+			fmt.Printf("- %-10s ← %s\n", r.Bucket, r.Path)
+		}
+	},
+}
+
+var repoRemoveCmd = &cobra.Command{
+	Use:   "remove [path]",
+	Short: "Remove a folder mapping",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := "."
+		if len(args) == 1 {
+			path = args[0]
+		}
+		abs, err := resolveRepoPath(path)
+		if err != nil {
+			log.Fatalf("  Invalid path: %v", err)
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		if !cfg.UnmapRepo(abs) {
+			fmt.Printf("  %s is not mapped.\n", abs)
+			os.Exit(1)
+		}
+		if err := internal.SaveConfig(cfg); err != nil {
+			log.Fatalf("  Failed to save config: %v", err)
+		}
+		fmt.Printf("🗑️  Removed mapping for %s\n", abs)
+	},
+}
+
+// resolveRepoPath makes path absolute and widens it to the enclosing git
+// repository root, so mapping any subfolder maps the whole repository.
+func resolveRepoPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return "", err
+	}
+	if root, _, ok := internal.FindGitDir(abs); ok {
+		return root, nil
+	}
+	return abs, nil
+}
+
+// workingDirContext returns the bucket mapped to the current directory and,
+// inside a git repository, a task hint from its branch or last commit. Both
+// are empty when the directory is not mapped.
+func workingDirContext() (bucket, task string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", ""
+	}
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return "", ""
+	}
+	bucket = cfg.BucketForDir(wd)
+	if bucket == "" {
+		return "", ""
+	}
+	if _, gitDir, ok := internal.FindGitDir(wd); ok {
+		task = internal.GitTaskHint(gitDir)
+	}
+	return bucket, task
+}
@@ -10,10 +10,15 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/srikanth-karthi/timesheet/internal"
-	"github.com/srikanth-karthi/timesheet/internal/setup"
+	"github.com/srikanth-karthi/timesheet/internal/store"
 )
 
-var showAll bool
+var (
+	showAll       bool
+	reportTags    []string
+	reportGroupBy string
+	reportTeam    bool
+)
 
 var reportCmd = &cobra.Command{
 	Use:   "report",
@@ -24,8 +29,13 @@ var reportCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		provider := setup.GetCredentialProvider()
-		srv := setup.GetSheetsService(provider)
+		switch reportGroupBy {
+		case "project", "tag", "ticket":
+		default:
+			log.Fatalf("  Invalid --group-by '%s'. Use project, tag or ticket.", reportGroupBy)
+		}
+
+		client := getSheetsClient()
 		userSheet := internal.CurrentUserID
 
 		now := time.Now()
@@ -36,57 +46,58 @@ var reportCmd = &cobra.Command{
 		monday := now.AddDate(0, 0, -weekday+1)
 		sunday := monday.AddDate(0, 0, 6)
 
-		resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!A5:G").Do()
+		if reportTeam {
+			teamReport(client, monday, sunday, internal.NormalizeTags(reportTags))
+			return
+		}
+
+		rows, err := fetchEntries(client, userSheet)
 		if err != nil {
 			log.Fatalf("  Failed to fetch timesheet data: %v", err)
 		}
 
-		type Entry struct {
-			Date        time.Time
-			Project     string
-			Description string
-			Hours       float64
-		}
+		applyParallelPolicy(rows)
 
-		var entries []Entry
-		for _, row := range resp.Values {
-			if len(row) < 5 {
-				continue
-			}
+		filterTags := internal.NormalizeTags(reportTags)
 
-			dateStr := fmt.Sprintf("%v", row[0])
-			project := fmt.Sprintf("%v", row[2])
-			description := fmt.Sprintf("%v", row[3])
-			hoursStr := fmt.Sprintf("%v", row[4])
-
-			date, err := time.Parse("02/01/06", dateStr)
-			if err != nil {
+		var entries []sheetEntry
+		for _, e := range rows {
+			if !showAll && (e.Date.Before(monday) || e.Date.After(sunday)) {
 				continue
 			}
-
-			if !showAll && (date.Before(monday) || date.After(sunday)) {
+			if len(filterTags) > 0 && !e.hasAnyTag(filterTags) {
 				continue
 			}
-
-			var hrs float64
-			fmt.Sscanf(hoursStr, "%f", &hrs)
-
-			entries = append(entries, Entry{
-				Date:        date,
-				Project:     project,
-				Description: description,
-				Hours:       hrs,
-			})
+			entries = append(entries, e)
 		}
 
-		daily := map[string][]Entry{}
+		daily := map[string][]sheetEntry{}
 		projectTotals := map[string]float64{}
+		tagTotals := map[string]float64{}
+		ticketTotals := map[string]float64{}
 		total := 0.0
+		leave := 0.0
 
 		for _, e := range entries {
 			key := e.Date.Format("Mon (Jan 02)")
 			daily[key] = append(daily[key], e)
-			projectTotals[e.Project] += e.Hours
+			// Leave is not worked time; it lowers the target instead.
+			if e.Bucket == leaveBucket {
+				leave += e.Hours
+				continue
+			}
+			projectTotals[e.Bucket] += e.Hours
+			if len(e.Tags) == 0 {
+				tagTotals["(untagged)"] += e.Hours
+			}
+			for _, t := range e.Tags {
+				tagTotals[t] += e.Hours
+			}
+			if e.Ticket == "" {
+				ticketTotals["(no ticket)"] += e.Hours
+			} else {
+				ticketTotals[e.Ticket] += e.Hours
+			}
 			total += e.Hours
 		}
 
@@ -112,19 +123,191 @@ var reportCmd = &cobra.Command{
 		for _, k := range keys {
 			fmt.Printf("%s\n", k)
 			for _, e := range daily[k] {
-				fmt.Printf("  - %-10s → %-30s → %.1f hrs\n", e.Project, e.Description, e.Hours)
+				fmt.Printf("  - %-10s → %-30s → %.1f hrs\n", e.Bucket, e.Task, e.Hours)
 			}
 			fmt.Println()
 		}
 
 		fmt.Println(strings.Repeat("-", 30))
-		fmt.Println("📁 Project Totals:")
+		switch reportGroupBy {
+		case "tag":
+			fmt.Println("🏷️ Tag Totals:")
+			printTotals(tagTotals)
+		case "ticket":
+			fmt.Println("🎫 Ticket Totals:")
+			printTotals(ticketTotals)
+		default:
+			fmt.Println("📁 Project Totals:")
+			for project, hrs := range projectTotals {
+				fmt.Printf("- %-10s → %.1f hrs\n", project, hrs)
+			}
+		}
+
+		settings, err := loadBucketSettings(client)
+		if err != nil {
+			log.Printf("⚠️ Could not load bucket rates: %v", err)
+		}
+		overrides, err := loadRateOverrides(client)
+		if err != nil {
+			log.Printf("⚠️ Could not load rate overrides: %v", err)
+		}
+
+		billableHours := 0.0
+		amounts := map[string]float64{}
+		var billableLines []string
 		for project, hrs := range projectTotals {
-			fmt.Printf("- %-10s → %.1f hrs\n", project, hrs)
+			setting, ok := settings[project]
+			if !ok || !setting.Billable {
+				continue
+			}
+			rate := rateFor(setting, overrides, userSheet)
+			amount := hrs * rate
+			billableHours += hrs
+			amounts[setting.Currency] += amount
+			billableLines = append(billableLines, fmt.Sprintf("- %-10s → %.1f hrs × %.2f = %.2f %s", project, hrs, rate, amount, setting.Currency))
 		}
 
-		fmt.Printf("\n🕒 Total Hours: %.1f\n\n", total)
+		if len(billableLines) > 0 {
+			sort.Strings(billableLines)
+			fmt.Println("\n💰 Billable:")
+			for _, line := range billableLines {
+				fmt.Println(line)
+			}
+			fmt.Printf("\n💵 Billable Hours: %.1f of %.1f\n", billableHours, total)
+			for currency, amount := range amounts {
+				fmt.Printf("💵 Billable Amount: %.2f %s\n", amount, currency)
+			}
+		}
+
+		fmt.Printf("\n🕒 Total Hours: %.1f\n", total)
+		if leave > 0 {
+			fmt.Printf("🏖️ Leave: %.1f hrs\n", leave)
+		}
+
+		var reported []string
+		for project := range projectTotals {
+			reported = append(reported, project)
+		}
+		if usages, err := budgetUsages(client, reported); err != nil {
+			log.Printf("⚠️ Could not load budgets: %v", err)
+		} else if len(usages) > 0 {
+			fmt.Println("\n📊 Budgets:")
+			for _, u := range usages {
+				fmt.Printf("- %-10s → %.1f of %.1f hrs %s, %.1f left (%.0f%%)\n", u.Bucket, u.Used, u.Budget, periodLabel(u.Period), u.Remaining(), u.Percent())
+			}
+		}
+
+		if len(filterTags) == 0 {
+			from, to := monday, sunday
+			if showAll {
+				from = now
+				for _, e := range entries {
+					if e.Date.Before(from) {
+						from = e.Date
+					}
+				}
+			}
+			fmt.Println()
+			printTarget(client, userSheet, rows, from, to)
+		}
+		fmt.Println()
 	},
 }
 
+// printTarget compares logged hours with the target over the period up to
+// today and prints the running overtime balance.
+func printTarget(client store.Client, userSheet string, entries []sheetEntry, from, to time.Time) {
+	cfg, source := loadContract(client, userSheet)
+	holidays, err := loadHolidays(client, userSheet)
+	if err != nil {
+		log.Printf("⚠️ Could not load holidays: %v", err)
+	}
+
+	now := time.Now().In(cfg.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if to.After(today) {
+		to = today
+	}
+	p := measurePeriod(cfg, entries, holidays, from, to)
+
+	fmt.Printf("🎯 Target: %.1f hrs (%d working days", p.Target, p.WorkDays)
+	if p.Holidays > 0 {
+		fmt.Printf(", %d holidays", p.Holidays)
+	}
+	if p.Leave > 0 {
+		fmt.Printf(", %.1f hrs leave", p.Leave)
+	}
+	fmt.Printf(", %s)\n", source)
+	fmt.Printf("⚖️  Logged: %.1f hrs (%+.1f)\n", p.Logged, p.Diff())
+
+	since := time.Time{}
+	if cfg.OvertimeSince != "" {
+		since, _ = time.Parse("2006-01-02", cfg.OvertimeSince)
+	}
+	if since.IsZero() {
+		for _, e := range entries {
+			if since.IsZero() || e.Date.Before(since) {
+				since = e.Date
+			}
+		}
+	}
+	if since.IsZero() || since.After(today) {
+		return
+	}
+
+	weeks := overtimeBalance(cfg, entries, holidays, since, today)
+	if showAll {
+		fmt.Println("\n📈 Overtime by week:")
+		fmt.Printf("  %-12s %8s %8s %8s %9s\n", "week of", "target", "logged", "diff", "balance")
+		for _, w := range weeks {
+			fmt.Printf("  %-12s %8.1f %8.1f %+8.1f %+9.1f\n", w.Monday.Format("2006-01-02"), w.period.Target, w.period.Logged, w.period.Diff(), w.Balance)
+		}
+	}
+	if len(weeks) > 0 {
+		fmt.Printf("📈 Overtime balance: %+.1f hrs since %s\n", weeks[len(weeks)-1].Balance, since.Format("2006-01-02"))
+	}
+}
+
+func printTotals(totals map[string]float64) {
+	keys := make([]string, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return totals[keys[i]] > totals[keys[j]] })
+	for _, k := range keys {
+		fmt.Printf("- %-10s → %.1f hrs\n", k, totals[k])
+	}
+}
+
+// applyParallelPolicy shares the time of parallel timers in entries when
+// the configured parallel policy is split. Every total of logged hours goes
+// through it, so report, budgets, targets and dashboards agree.
+func applyParallelPolicy(entries []sheetEntry) {
+	if cfg, err := internal.LoadConfig(); err == nil && cfg.ParallelPolicy() == internal.ParallelSplit {
+		shareParallelTime(entries)
+	}
+}
 
+// shareParallelTime rewrites the hours of timer rows so that time during
+// which several timers ran is divided between them instead of counted once
+// per timer. Manually logged rows are left alone.
+func shareParallelTime(entries []sheetEntry) {
+	var idx []int
+	var spans []internal.Span
+	for i, e := range entries {
+		if e.Timer == "" || e.Hours <= 0 {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		idx = append(idx, i)
+		spans = append(spans, internal.Span{Start: start, End: start.Add(time.Duration(e.Hours * float64(time.Hour)))})
+	}
+	for j, hours := range internal.ShareOverlaps(spans) {
+		entries[idx[j]].Hours = hours
+	}
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestShareParallelTime(t *testing.T) {
+	entries := []sheetEntry{
+		{Bucket: "general", Hours: 2, Timestamp: "2026-10-12T09:00:00Z", Timer: "default"},
+		{Bucket: "ops", Hours: 2, Timestamp: "2026-10-12T10:00:00Z", Timer: "build"},
+		// Logged by hand: keeps its hours even though it overlaps.
+		{Bucket: "general", Hours: 1, Timestamp: "2026-10-12T09:30:00Z"},
+		{Bucket: "general", Hours: 1, Timestamp: "not a time", Timer: "default"},
+		{Bucket: "general", Hours: 0, Timestamp: "2026-10-12T09:00:00Z", Timer: "default"},
+	}
+	shareParallelTime(entries)
+
+	want := []float64{1.5, 1.5, 1, 1, 0}
+	for i, e := range entries {
+		if e.Hours != want[i] {
+			t.Errorf("entry %d has %v hours, want %v", i, e.Hours, want[i])
+		}
+	}
+}
@@ -1,20 +1,29 @@
 package cmd
 
 import (
-	"context"
+	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/srikanth-karthi/timesheet/internal"
-	"google.golang.org/api/option"
-	"google.golang.org/api/sheets/v4"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "timesheet",
 	Short: "CLI timesheet tracker for logging and managing work logs",
 	Long:  `Track, manage, and report timesheets directly from the terminal.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if strings.HasPrefix(cmd.Name(), "__") {
+			return // shell completion requests are not user activity
+		}
+		var err error
+		lastInvocation, err = internal.TouchInvocation(time.Now())
+		if err != nil {
+			fmt.Printf("⚠️ %v\n", err)
+		}
+	},
 }
 
 func Execute() {
@@ -25,7 +34,6 @@ func Execute() {
 
 func init() {
 
-
 	rootCmd.PersistentFlags().BoolP("toggle", "t", false, "Help message for toggle")
 
 	rootCmd.AddCommand(
@@ -35,17 +43,114 @@ func init() {
 		logCmd,
 		reportCmd,
 		bucketCmd,
+		invoiceCmd,
+		entriesCmd,
+		repoCmd,
+		suggestCmd,
+		daemonCmd,
+		configCmd,
+		statusCmd,
+		switchCmd,
+		templateCmd,
+		leaveCmd,
+		targetCmd,
+		budgetCmd,
+		submitCmd,
+		reopenCmd,
+		reviewCmd,
+		approveCmd,
+		rejectCmd,
+		adminCmd,
+		publishCmd,
+		repairSheetCmd,
 	)
 
-	bucketCmd.AddCommand(bucketNewCmd, bucketListCmd)
+	bucketCmd.AddCommand(bucketNewCmd, bucketListCmd, bucketSetCmd)
+	repoCmd.AddCommand(repoAddCmd, repoListCmd, repoRemoveCmd)
+	daemonCmd.AddCommand(daemonStatusCmd, daemonStopCmd)
+	configCmd.AddCommand(configSetCmd)
+	adminCmd.AddCommand(adminUsersCmd)
+	adminUsersCmd.AddCommand(adminUsersListCmd, adminUsersAddCmd, adminUsersDisableCmd, adminUsersEnableCmd, adminUsersResetCmd, adminUsersDeleteCmd)
+	targetCmd.AddCommand(targetSetCmd)
+	leaveCmd.AddCommand(leaveAddCmd, leaveListCmd, leaveImportCmd)
+	templateCmd.AddCommand(templateAddCmd, templateListCmd, templateRemoveCmd, templateApplyCmd)
 
 	setupCmd.Flags().BoolVar(&createUser, "create", false, "Create a new user during setup")
+	setupCmd.Flags().BoolVar(&setupPromote, "promote", false, "Claim the admin role when no user has it yet")
 	startCmd.Flags().StringVar(&bucketFlag, "bucket", "", "Bucket to log task in")
+	startCmd.Flags().StringArrayVar(&startTags, "tag", nil, "Tag for the task (repeatable, #hashtags in the description are added too)")
+	startCmd.Flags().StringVarP(&startMessage, "message", "m", "", "Task description (skips the prompt)")
+	startCmd.Flags().BoolVar(&startContinue, "continue", false, "Restart the most recent task in its bucket")
+	startCmd.Flags().StringVar(&startID, "id", "", "Name of the timer, to run several in parallel")
+	startCmd.Flags().StringVar(&startTicket, "ticket", "", "Issue reference, e.g. PROJ-123 (detected from the description if omitted)")
+	switchCmd.Flags().StringVarP(&switchMessage, "message", "m", "", "Description of the new task (prompted if omitted)")
+	switchCmd.Flags().StringVar(&switchID, "id", "", "Timer to switch (default: the default timer)")
+	switchCmd.Flags().StringArrayVar(&switchTags, "tag", nil, "Tag for the new task (repeatable, #hashtags in the description are added too)")
+	switchCmd.Flags().StringVar(&switchTicket, "ticket", "", "Issue reference for the new task (detected from the description if omitted)")
+	stopCmd.Flags().BoolVar(&stopAll, "all", false, "Stop every running timer")
 	logCmd.Flags().StringVar(&logTask, "task", "", "Task description (required)")
 	logCmd.Flags().StringVar(&logHours, "hours", "", "Hours spent (required)")
 	logCmd.Flags().StringVar(&logBucket, "bucket", "", "Bucket/project name (optional)")
 	logCmd.Flags().StringVar(&logDate, "date", "", "Date in dd/mm/yy format (optional)")
+	logCmd.Flags().StringArrayVar(&logTags, "tag", nil, "Tag for the task (repeatable, #hashtags in the description are added too)")
+	logCmd.Flags().StringVar(&logTicket, "ticket", "", "Issue reference, e.g. PROJ-123 (detected from the description if omitted)")
+	bucketSetCmd.Flags().BoolVar(&bucketBillable, "billable", false, "Mark the bucket as billable (use --billable=false to clear)")
+	bucketSetCmd.Flags().Float64Var(&bucketRate, "rate", 0, "Hourly rate")
+	bucketSetCmd.Flags().StringVar(&bucketCurrency, "currency", "", "Currency code for the rate, e.g. EUR")
+	bucketSetCmd.Flags().StringVar(&bucketClient, "client", "", "Client the bucket is billed to")
+	bucketSetCmd.Flags().StringVar(&bucketRateUser, "user", "", "EMP ID to override the rate for")
+	bucketSetCmd.Flags().Float64Var(&bucketBudget, "budget", 0, "Hour budget shared by everyone logging to the bucket (0 to clear)")
+	bucketSetCmd.Flags().StringVar(&bucketPeriod, "budget-period", budgetTotal, "Period the budget covers: total, month or week")
 	reportCmd.Flags().BoolVarP(&showAll, "all", "a", false, "Show all entries instead of just this week")
+	reportCmd.Flags().StringArrayVar(&reportTags, "tag", nil, "Only include entries with this tag (repeatable)")
+	reportCmd.Flags().BoolVar(&reportTeam, "team", false, "Report on your direct reports (everyone for admins)")
+	reportCmd.Flags().StringVar(&reportGroupBy, "group-by", "project", "Aggregate totals by: project, tag or ticket")
+	repoAddCmd.Flags().StringVar(&repoBucket, "bucket", "", "Bucket to map the folder to (required)")
+	suggestCmd.Flags().StringVar(&suggestFrom, "from", "", "First day to scan in dd/mm/yy format (default: today)")
+	suggestCmd.Flags().StringVar(&suggestTo, "to", "", "Last day to scan in dd/mm/yy format (default: --from)")
+	suggestCmd.Flags().StringVar(&suggestAuthor, "author", "", "Commit author to match (default: git user.email of each repository)")
+	suggestCmd.Flags().DurationVar(&suggestGap, "gap", 2*time.Hour, "Start a new session after this long without commits")
+	suggestCmd.Flags().DurationVar(&suggestLead, "lead", 30*time.Minute, "Time credited before the first commit of a session")
+	daemonCmd.Flags().DurationVar(&daemonFlushInterval, "flush-interval", 250*time.Millisecond, "How long writes are held to be batched together")
+	daemonCmd.Flags().DurationVar(&daemonCacheTTL, "cache-ttl", 5*time.Minute, "How long reads are served from cache")
+	templateAddCmd.Flags().StringVar(&templateBucket, "bucket", "", "Bucket to log the entry in (required)")
+	templateAddCmd.Flags().Float64Var(&templateHours, "hours", 0, "Hours per occurrence (required)")
+	templateAddCmd.Flags().StringVar(&templateTask, "task", "", "Task description (required)")
+	templateAddCmd.Flags().StringVar(&templateOn, "on", "mon-fri", "Days it recurs on, e.g. mon-fri, mon,wed or daily")
+	templateApplyCmd.Flags().BoolVar(&templateWeek, "week", false, "Apply for the whole week (Monday to Sunday)")
+	templateApplyCmd.Flags().StringVar(&templateDate, "date", "", "Day to apply for, or a day in the week with --week (dd/mm/yy, default: today)")
+	templateApplyCmd.Flags().BoolVar(&templateDry, "dry-run", false, "Show what would be logged without writing")
+	leaveAddCmd.Flags().StringVar(&leaveType, "type", "vacation", "Kind of leave, e.g. vacation, sick or unpaid")
+	leaveAddCmd.Flags().Float64Var(&leaveHours, "hours", 0, "Hours of leave per day (default: hours-per-day setting)")
+	leaveListCmd.Flags().BoolVar(&leaveAll, "all", false, "List all years instead of just this one")
+	leaveImportCmd.Flags().BoolVar(&leavePersonal, "personal", false, "Import the holidays for yourself only instead of everyone")
+	targetSetCmd.Flags().StringVar(&targetUser, "user", "", "EMP ID to set the contract for in the shared targets tab")
+	targetSetCmd.Flags().Float64Var(&targetDaily, "daily", 0, "Contracted hours per working day")
+	targetSetCmd.Flags().Float64Var(&targetWeekly, "weekly", 0, "Contracted hours per week, spread over the work days")
+	targetSetCmd.Flags().StringVar(&targetDays, "days", "", "Working days, e.g. mon-fri or mon-thu")
+	submitCmd.Flags().BoolVar(&submitWeek, "week", false, "Submit a whole week (the default)")
+	submitCmd.Flags().StringVar(&submitDate, "date", "", "A day in the week to submit, yyyy-mm-dd or dd/mm/yy (default: this week)")
+	reopenCmd.Flags().StringVar(&reopenUser, "user", "", "EMP ID whose week to reopen (default: yourself)")
+	reopenCmd.Flags().StringVar(&reopenDate, "date", "", "A day in the week to reopen, yyyy-mm-dd or dd/mm/yy (default: this week)")
+	reviewCmd.Flags().BoolVar(&reviewAll, "all", false, "Show submitted weeks of all users (admin only)")
+	approveCmd.Flags().StringVar(&decisionDate, "date", "", "A day in the week to approve, yyyy-mm-dd or dd/mm/yy (default: this week)")
+	rejectCmd.Flags().StringVar(&decisionDate, "date", "", "A day in the week to reject, yyyy-mm-dd or dd/mm/yy (default: this week)")
+	rejectCmd.Flags().StringVar(&rejectReason, "reason", "", "Why the week is rejected (required)")
+	adminUsersAddCmd.Flags().StringVar(&adminRole, "role", roleEmployee, "Role: employee, manager or admin")
+	adminUsersAddCmd.Flags().StringVar(&adminManager, "manager", "", "EMP ID of the user's manager")
+	adminUsersAddCmd.Flags().StringVar(&adminPassword, "password", "", "Initial password (prompted if omitted)")
+	adminUsersResetCmd.Flags().StringVar(&adminPassword, "password", "", "New password (prompted if omitted)")
+	publishCmd.Flags().IntVar(&publishWeeks, "weeks", 12, "Number of weeks to include, ending with the current one")
+	repairSheetCmd.Flags().BoolVar(&repairAll, "all", false, "Repair every user's sheet (admin only)")
+	entriesCmd.Flags().StringVar(&entriesTicket, "ticket", "", "Only show entries for this ticket")
+	entriesCmd.Flags().StringVar(&entriesBucket, "bucket", "", "Only show entries in this bucket")
+	entriesCmd.Flags().StringVar(&entriesTag, "tag", "", "Only show entries with this tag")
+	invoiceCmd.Flags().StringVar(&invoiceClient, "client", "", "Client to invoice (required)")
+	invoiceCmd.Flags().StringVar(&invoiceMonth, "month", "", "Billing month in yyyy-mm format (default: current month)")
+	invoiceCmd.Flags().StringVar(&invoiceFormat, "format", "md", "Output format: md, html or pdf")
+	invoiceCmd.Flags().StringVar(&invoiceTemplate, "template", "", "Path to a custom Go template")
+	invoiceCmd.Flags().StringVarP(&invoiceOut, "out", "o", "", "Output file (default: <invoice number>.<format>)")
+	invoiceCmd.Flags().BoolVar(&invoiceMark, "mark", false, "Mark the billed entries as invoiced so they are not billed twice")
 
 	bucketCmd.ValidArgsFunction = completeBuckets
 }
@@ -56,21 +161,18 @@ func completeBuckets(cmd *cobra.Command, args []string, toComplete string) ([]st
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	ctx := context.Background()
+	// Completion runs on every keystroke and only reads bucket names, so
+	// it skips the session check against the admin tab.
 	userSheet := internal.CurrentUserID
+	client := sheetsClient()
 
-	srv, err := sheets.NewService(ctx, option.WithCredentialsFile("credentials.json"))
-	if err != nil {
-		return nil, cobra.ShellCompDirectiveNoFileComp
-	}
-
-	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!C1:Z1").Do()
-	if err != nil || len(resp.Values) == 0 {
+	resp, err := client.Get(userSheet + "!C1:Z1")
+	if err != nil || len(resp) == 0 {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
 	var suggestions []string
-	for _, cell := range resp.Values[0] {
+	for _, cell := range resp[0] {
 		if str, ok := cell.(string); ok && strings.HasPrefix(str, toComplete) {
 			suggestions = append(suggestions, str)
 		}
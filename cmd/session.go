@@ -0,0 +1,493 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// activityGrace ignores activity right before 'stop' itself, such as the
+// shell history entry for the stop command.
+const activityGrace = 2 * time.Minute
+
+// timerLockWait is how long a command waits for another one to finish
+// writing a timer's row; Sheets retries can take a while.
+const timerLockWait = 5 * time.Minute
+
+// lastInvocation is when the CLI last ran before this invocation.
+var lastInvocation time.Time
+
+// loadMeta reads the local session state. A corrupted meta.json has been
+// set aside by the time this returns; the user is told where.
+func loadMeta() *internal.Meta {
+	meta, err := internal.LoadMeta()
+	var corrupt *internal.CorruptMetaError
+	if errors.As(err, &corrupt) {
+		fmt.Printf("⚠️ %v\n", err)
+		return meta
+	}
+	if err != nil {
+		log.Fatalf("  Failed to read session state: %v", err)
+	}
+	return meta
+}
+
+// updateMeta applies change to meta.json, reloaded under its lock so other
+// terminals' changes survive, and then to the in-memory meta.
+func updateMeta(meta *internal.Meta, change func(m *internal.Meta) error) {
+	if err := internal.UpdateMeta(change); err != nil {
+		log.Fatalf("  Failed to save session: %v", err)
+	}
+	_ = change(meta)
+}
+
+// lockTimers keeps other commands from writing or settling timers until the
+// returned func is called.
+func lockTimers() func() {
+	release, err := internal.LockTimers(0)
+	if errors.Is(err, internal.ErrLocked) {
+		fmt.Println("⏳ Waiting for another timesheet command to finish writing its timer...")
+		release, err = internal.LockTimers(timerLockWait)
+	}
+	if err != nil {
+		log.Fatalf("  Failed to lock session: %v", err)
+	}
+	return release
+}
+
+// userLocation is the configured timezone for dates written to the sheet.
+func userLocation() *time.Location {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		return time.Local
+	}
+	return cfg.Location()
+}
+
+// findSessionEntry returns the row that 'start' wrote for timer id starting
+// at sessionStart. Rows from before named timers have no timer column.
+func findSessionEntry(entries []sheetEntry, sessionStart, id string) *sheetEntry {
+	want, err := time.Parse(time.RFC3339, sessionStart)
+	if err != nil {
+		return nil
+	}
+	for i, e := range entries {
+		if e.Timer != "" && e.Timer != id {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err == nil && ts.Equal(want) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// closeTimer books timer id up to end and removes it from meta and
+// meta.json. With review, overlong sessions are offered for trimming first.
+// The timer is saved as stopping before any hours are written, so a stop
+// that dies half way is finished by recoverTimers. It returns the hours
+// logged.
+func closeTimer(client store.Client, userSheet string, entries []sheetEntry, meta *internal.Meta, id string, end time.Time, review bool) float64 {
+	timer := meta.Timers[id]
+	start, err := time.Parse(time.RFC3339, timer.Start)
+	if err != nil {
+		log.Fatalf("  Invalid start time for timer '%s': %v", id, err)
+	}
+
+	ensureWeeksOpen(client, userSheet, start.In(userLocation()), end.In(userLocation()))
+
+	release := lockTimers()
+	defer release()
+	finishSwitch(client, userSheet, entries, meta, id)
+
+	if !canBook(entries, id, timer) {
+		updateMeta(meta, stopTimer(id))
+		return 0
+	}
+	spans := sessionSpans(start, end, review)
+
+	timer.State = internal.TimerStopping
+	timer.End = spans[len(spans)-1].End.Format(time.RFC3339)
+	updateMeta(meta, func(m *internal.Meta) error {
+		if current, ok := m.Timers[id]; !ok || current.Start != timer.Start {
+			return fmt.Errorf("timer '%s' was stopped by another command", id)
+		}
+		m.Timers[id] = timer
+		return nil
+	})
+
+	hours, err := bookTimer(client, userSheet, entries, id, timer)
+	if err != nil {
+		if temporaryFailure(err) {
+			log.Fatalf("  Failed to update hours: %v\n   The timer is saved as stopping and finished on the next command.", err)
+		}
+		log.Fatalf("  Failed to update hours: %v\n   The timer is saved as stopping; fix the error above, then any command finishes it.", err)
+	}
+	updateMeta(meta, stopTimer(id))
+	return hours
+}
+
+// sessionSpans returns how a session from start to end is booked, one span
+// per day. With review, overlong sessions are offered for trimming first.
+func sessionSpans(start, end time.Time, review bool) []internal.Span {
+	var spans []internal.Span
+	if review {
+		spans = resolveSessionEnd(start, end)
+	} else {
+		spans = internal.SplitByDay(start, end, userLocation())
+	}
+	if len(spans) == 0 {
+		spans = []internal.Span{{Start: start, End: end}}
+	}
+	return spans
+}
+
+// canBook reports whether the hours of timer can be written: to its row,
+// or to a new row built from the bucket and task kept in meta when the row
+// is missing.
+func canBook(entries []sheetEntry, id string, timer internal.Timer) bool {
+	if findSessionEntry(entries, timer.Start, id) != nil {
+		return true
+	}
+	if timer.Bucket == "" {
+		fmt.Println("⚠️ Could not find previous session row to log hours.")
+		return false
+	}
+	fmt.Println("⚠️ The session's row is missing from the sheet; logging it as a new row.")
+	return true
+}
+
+// bookTimer writes the hours of a stopping timer from its start up to its
+// recorded end and returns them. Rows an earlier attempt already wrote are
+// not written twice.
+func bookTimer(client store.Client, userSheet string, entries []sheetEntry, id string, timer internal.Timer) (float64, error) {
+	start, err := time.Parse(time.RFC3339, timer.Start)
+	if err != nil {
+		return 0, err
+	}
+	end, err := time.Parse(time.RFC3339, timer.End)
+	if err != nil {
+		return 0, err
+	}
+	entry := findSessionEntry(entries, timer.Start, id)
+	if entry == nil {
+		if timer.Bucket == "" {
+			return 0, fmt.Errorf("no row for the session started %s", timer.Start)
+		}
+		entry = timerEntry(id, timer)
+	}
+
+	spans := internal.SplitByDay(start, end, userLocation())
+	if len(spans) == 0 {
+		spans = []internal.Span{{Start: start, End: end}}
+	}
+	if err := writeSessionSpans(client, userSheet, *entry, spans, entries); err != nil {
+		return 0, err
+	}
+	return sumSpans(spans), nil
+}
+
+// finishSwitch books the session a 'switch' replaced with timer id, if its
+// hours are not written yet. The caller holds the timers lock.
+func finishSwitch(client store.Client, userSheet string, entries []sheetEntry, meta *internal.Meta, id string) {
+	previous := meta.Timers[id].Previous
+	if previous == nil {
+		return
+	}
+	hours, err := bookTimer(client, userSheet, entries, id, *previous)
+	if err != nil {
+		log.Fatalf("  Failed to close '%s' before timer '%s': %v", previous.Task, id, err)
+	}
+	updateMeta(meta, clearPrevious(id))
+	fmt.Printf("🩹 Closed '%s' from an earlier switch: %.2f hrs logged\n", previous.Task, hours)
+}
+
+// recoverTimers settles timers left unsettled by a command that died
+// between saving meta and writing the sheet:
+//
+//   - A pending timer whose row reached the sheet is kept running. Without
+//     a row it is dropped, as its start was never confirmed; if a 'switch'
+//     started it, the session it replaced is running again instead.
+//   - A stopping timer has its hours written again up to the recorded end.
+//   - The session a successful 'switch' replaced has its hours written.
+//
+// Failures only warn; the timers stay unsettled for the next command.
+// Timers of a command still writing are left alone.
+func recoverTimers(client store.Client, userSheet string, meta *internal.Meta) {
+	if len(meta.Unsettled()) == 0 {
+		return
+	}
+	release, err := internal.LockTimers(0)
+	if err != nil {
+		return
+	}
+	defer release()
+
+	// Reload: the command that held the lock may have settled them.
+	fresh := loadMeta()
+	*meta = *fresh
+	ids := meta.Unsettled()
+	if len(ids) == 0 {
+		return
+	}
+	entries, err := fetchEntries(client, userSheet)
+	if err != nil {
+		log.Printf("⚠️ Could not check interrupted timers against the sheet: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		timer := meta.Timers[id]
+		entry := findSessionEntry(entries, timer.Start, id)
+
+		switch timer.State {
+		case internal.TimerPending:
+			if entry == nil && timer.Previous != nil {
+				fmt.Printf("🩹 Switch to '%s' never reached the sheet; '%s' is running again.\n", timer.Task, timer.Previous.Task)
+				updateMeta(meta, resumePrevious(id))
+				continue
+			}
+			if entry == nil {
+				fmt.Printf("🩹 Timer '%s' (%s) never reached the sheet and was discarded.\n", id, timer.Task)
+				updateMeta(meta, stopTimer(id))
+				continue
+			}
+			updateMeta(meta, commitTimer(id))
+
+		case internal.TimerStopping:
+			hours, err := bookTimer(client, userSheet, entries, id, timer)
+			if err != nil {
+				log.Printf("⚠️ Could not finish stopping timer '%s': %v", id, err)
+				continue
+			}
+			updateMeta(meta, stopTimer(id))
+			fmt.Printf("🩹 Finished stopping timer '%s' (%s): %.2f hrs logged\n", id, timer.Task, hours)
+			continue
+		}
+
+		if previous := timer.Previous; previous != nil {
+			hours, err := bookTimer(client, userSheet, entries, id, *previous)
+			if err != nil {
+				log.Printf("⚠️ Could not close '%s' from an earlier switch: %v", previous.Task, err)
+				continue
+			}
+			updateMeta(meta, clearPrevious(id))
+			fmt.Printf("🩹 Closed '%s' from an earlier switch: %.2f hrs logged\n", previous.Task, hours)
+		}
+	}
+}
+
+// startTimer is the updateMeta change that adds a pending timer. It fails
+// if another command started the same timer id in the meantime.
+func startTimer(id string, timer internal.Timer) func(m *internal.Meta) error {
+	return func(m *internal.Meta) error {
+		if current, ok := m.Timers[id]; ok && current.Start != timer.Start {
+			return fmt.Errorf("timer '%s' was started by another command at %s", id, current.Start)
+		}
+		m.StartTimer(id, timer)
+		return nil
+	}
+}
+
+func commitTimer(id string) func(m *internal.Meta) error {
+	return func(m *internal.Meta) error {
+		m.CommitTimer(id)
+		return nil
+	}
+}
+
+func stopTimer(id string) func(m *internal.Meta) error {
+	return func(m *internal.Meta) error {
+		m.StopTimer(id)
+		return nil
+	}
+}
+
+func clearPrevious(id string) func(m *internal.Meta) error {
+	return func(m *internal.Meta) error {
+		if t, ok := m.Timers[id]; ok {
+			t.Previous = nil
+			m.Timers[id] = t
+		}
+		return nil
+	}
+}
+
+// resumePrevious undoes a 'switch' whose new row never reached the sheet:
+// the replaced session runs on as if the switch never happened.
+func resumePrevious(id string) func(m *internal.Meta) error {
+	return func(m *internal.Meta) error {
+		t, ok := m.Timers[id]
+		if !ok || t.Previous == nil {
+			return nil
+		}
+		previous := *t.Previous
+		previous.State, previous.End = "", ""
+		m.Timers[id] = previous
+		return nil
+	}
+}
+
+// timerEntry stands in for a timer's row when it is missing from the sheet;
+// with no Row, writeSessionSpans appends every span.
+func timerEntry(id string, timer internal.Timer) *sheetEntry {
+	return &sheetEntry{
+		Bucket:    timer.Bucket,
+		Task:      timer.Task,
+		Timestamp: timer.Start,
+		Tags:      internal.ParseTags(timer.Task),
+		Ticket:    detectTicket(timer.Task),
+		Timer:     id,
+	}
+}
+
+func sumSpans(spans []internal.Span) float64 {
+	total := 0.0
+	for _, span := range spans {
+		total += span.Hours()
+	}
+	return total
+}
+
+// resolveSessionEnd decides how a session from start to now is booked. Long
+// sessions and ones crossing midnight are flagged and the user may keep
+// them, cap them at a time or drop the idle tail after the last observed
+// activity. The result is split at every midnight in the configured
+// timezone so each day gets its own row.
+func resolveSessionEnd(start, now time.Time) []internal.Span {
+	cfg, err := internal.LoadConfig()
+	if err != nil {
+		cfg = &internal.Config{}
+	}
+	loc := cfg.Location()
+	end := now
+
+	days := internal.SplitByDay(start, now, loc)
+	if now.Sub(start) > cfg.MaxSession() {
+		idle := internal.LastActivity(start, now.Add(-activityGrace), lastInvocation)
+
+		fmt.Printf("⚠️  This session ran %.2f hrs (started %s).\n", now.Sub(start).Hours(), start.In(loc).Format("Mon 02/01 15:04"))
+		fmt.Printf("   That is longer than the %.0f hr maximum.\n", cfg.MaxSession().Hours())
+		if len(days) > 1 {
+			fmt.Printf("   It crosses midnight into %d days.\n", len(days))
+		}
+		if !idle.IsZero() {
+			fmt.Printf("   Last activity seen at %s.\n", idle.In(loc).Format("Mon 02/01 15:04"))
+		}
+
+		fmt.Println("❓ How should it be logged?")
+		fmt.Printf("   [k]eep %.2f hrs\n", now.Sub(start).Hours())
+		fmt.Println("   [c]ap at a time")
+		if !idle.IsZero() {
+			fmt.Printf("   [i]dle: end at last activity (%.2f hrs)\n", idle.Sub(start).Hours())
+		}
+		fmt.Print("Choice (k): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "c", "cap":
+			def := start.Add(cfg.MaxSession())
+			if !idle.IsZero() {
+				def = idle
+			}
+			fmt.Printf("   End time (HH:MM or dd/mm/yy HH:MM) [%s]: ", def.In(loc).Format("02/01/06 15:04"))
+			input, _ := reader.ReadString('\n')
+			end = def
+			if input = strings.TrimSpace(input); input != "" {
+				end, err = parseEndTime(input, start, now, loc)
+				if err != nil {
+					log.Fatalf("  %v", err)
+				}
+			}
+		case "i", "idle":
+			if !idle.IsZero() {
+				end = idle
+			}
+		}
+	}
+
+	spans := internal.SplitByDay(start, end, loc)
+	if len(spans) > 1 {
+		fmt.Printf("🌙 Session crosses midnight; booking it as %d daily rows.\n", len(spans))
+	}
+	if len(spans) == 0 {
+		spans = []internal.Span{{Start: start, End: end}}
+	}
+	return spans
+}
+
+// parseEndTime accepts "15:04" (the latest such time not after now) or
+// "02/01/06 15:04" in loc and checks it lies within the session.
+func parseEndTime(input string, start, now time.Time, loc *time.Location) (time.Time, error) {
+	var end time.Time
+	if t, err := time.ParseInLocation("02/01/06 15:04", input, loc); err == nil {
+		end = t
+	} else if t, err := time.ParseInLocation("15:04", input, loc); err == nil {
+		local := now.In(loc)
+		end = time.Date(local.Year(), local.Month(), local.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+		if end.After(now) {
+			end = end.AddDate(0, 0, -1)
+		}
+	} else {
+		return time.Time{}, fmt.Errorf("invalid end time '%s'. Use HH:MM or dd/mm/yy HH:MM", input)
+	}
+
+	if !end.After(start) || end.After(now) {
+		return time.Time{}, fmt.Errorf("end time must be between %s and now", start.In(loc).Format("02/01/06 15:04"))
+	}
+	return end, nil
+}
+
+// writeSessionSpans books the spans of a session: the first one fills in the
+// hours of the session's own row, later ones (days after midnight) are
+// appended as new rows with the same bucket, task, tags and ticket. Rows
+// already in entries, left by an interrupted earlier stop, are not appended
+// again. An entry without a row gets all its spans appended.
+func writeSessionSpans(client store.Client, userSheet string, entry sheetEntry, spans []internal.Span, entries []sheetEntry) error {
+	if entry.Row > 0 {
+		first := fmt.Sprintf("%.2f", spans[0].Hours())
+		if err := client.Update(fmt.Sprintf("%s!E%d", userSheet, entry.Row), [][]interface{}{{first}}, "USER_ENTERED"); err != nil {
+			return err
+		}
+		spans = spans[1:]
+	}
+
+	var rows [][]interface{}
+	for _, span := range spans {
+		if hasSpanRow(entries, entry, span.Start) {
+			continue
+		}
+		rows = append(rows, []interface{}{
+			span.Start.Format("02/01/06"), span.Start.Format("Monday"), entry.Bucket, entry.Task,
+			fmt.Sprintf("%.2f", span.Hours()), span.Start.Format(time.RFC3339), "", internal.JoinTags(entry.Tags), entry.Ticket, entry.Timer,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return client.Append(userSheet+"!A3:J", rows, "USER_ENTERED")
+}
+
+// hasSpanRow reports whether entries already hold the row of a session
+// starting at start.
+func hasSpanRow(entries []sheetEntry, session sheetEntry, start time.Time) bool {
+	for _, e := range entries {
+		if e.Bucket != session.Bucket || e.Task != session.Task || e.Timer != session.Timer {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err == nil && ts.Equal(start) && e.Hours > 0 {
+			return true
+		}
+	}
+	return false
+}
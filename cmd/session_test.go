@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+const testSheet = "E1"
+
+// sessionSheet returns a user sheet holding rows from row 5 on.
+func sessionSheet(rows ...[]interface{}) *fakeSheets {
+	client := newFakeSheets()
+	client.tabs[testSheet] = append([][]interface{}{{"meta"}, {"Date"}, nil, nil}, rows...)
+	return client
+}
+
+func sessionRow(date, bucket, task, hours, start, timer string) []interface{} {
+	return []interface{}{date, "", bucket, task, hours, start, "", "", "", timer}
+}
+
+// recoverWith saves meta, runs recoverTimers and returns the meta it left.
+func recoverWith(t *testing.T, client *fakeSheets, meta *internal.Meta) *internal.Meta {
+	t.Helper()
+	if err := internal.SaveMeta(meta); err != nil {
+		t.Fatal(err)
+	}
+	recoverTimers(client, testSheet, meta)
+	saved, err := internal.LoadMeta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return saved
+}
+
+func TestRecoverTimersFinishesStop(t *testing.T) {
+	useTempConfig(t)
+	client := sessionSheet(sessionRow("12/10/26", "general", "review", "", "2026-10-12T09:00:00Z", "default"))
+
+	meta := recoverWith(t, client, &internal.Meta{Timers: map[string]internal.Timer{
+		"default": {Start: "2026-10-12T09:00:00Z", Bucket: "general", Task: "review", State: internal.TimerStopping, End: "2026-10-12T11:30:00Z"},
+	}})
+
+	if len(meta.Timers) != 0 {
+		t.Errorf("timers left: %+v", meta.Timers)
+	}
+	if got := client.cell(testSheet, 5, 5); got != "2.50" {
+		t.Errorf("hours = %v, want 2.50", got)
+	}
+	if len(client.calls) != 1 {
+		t.Errorf("writes %v, want one update", client.calls)
+	}
+}
+
+func TestRecoverTimersSplitRowWrittenOnce(t *testing.T) {
+	useTempConfig(t)
+	// The stop that died had already appended the row after midnight.
+	client := sessionSheet(
+		sessionRow("12/10/26", "general", "deploy", "", "2026-10-12T22:00:00Z", "default"),
+		sessionRow("13/10/26", "general", "deploy", "1.50", "2026-10-13T00:00:00Z", "default"),
+	)
+
+	meta := recoverWith(t, client, &internal.Meta{Timers: map[string]internal.Timer{
+		"default": {Start: "2026-10-12T22:00:00Z", Bucket: "general", Task: "deploy", State: internal.TimerStopping, End: "2026-10-13T01:30:00Z"},
+	}})
+
+	if len(meta.Timers) != 0 {
+		t.Errorf("timers left: %+v", meta.Timers)
+	}
+	if got := client.cell(testSheet, 5, 5); got != "2.00" {
+		t.Errorf("first day hours = %v, want 2.00", got)
+	}
+	if rows := len(client.tabs[testSheet]); rows != 6 {
+		t.Errorf("%d rows, want 6: the split row was appended again", rows)
+	}
+}
+
+func TestRecoverTimersMissingSplitRow(t *testing.T) {
+	useTempConfig(t)
+	client := sessionSheet(sessionRow("12/10/26", "general", "deploy", "", "2026-10-12T22:00:00Z", "default"))
+
+	recoverWith(t, client, &internal.Meta{Timers: map[string]internal.Timer{
+		"default": {Start: "2026-10-12T22:00:00Z", Bucket: "general", Task: "deploy", State: internal.TimerStopping, End: "2026-10-13T01:30:00Z"},
+	}})
+
+	if rows := len(client.tabs[testSheet]); rows != 6 {
+		t.Fatalf("%d rows, want 6", rows)
+	}
+	if got := client.cell(testSheet, 6, 5); got != "1.50" {
+		t.Errorf("second day hours = %v, want 1.50", got)
+	}
+}
+
+func TestRecoverTimersPending(t *testing.T) {
+	useTempConfig(t)
+	client := sessionSheet(sessionRow("12/10/26", "ops", "on call", "", "2026-10-12T09:00:00Z", "oncall"))
+
+	meta := recoverWith(t, client, &internal.Meta{Timers: map[string]internal.Timer{
+		// Its row reached the sheet: it keeps running.
+		"oncall": {Start: "2026-10-12T09:00:00Z", Bucket: "ops", Task: "on call", State: internal.TimerPending},
+		// Its row never did: it is dropped.
+		"build": {Start: "2026-10-12T10:00:00Z", Bucket: "ops", Task: "build", State: internal.TimerPending},
+	}})
+
+	if timer, ok := meta.Timers["oncall"]; !ok || timer.State != "" {
+		t.Errorf("oncall = %+v (%v), want it running", timer, ok)
+	}
+	if _, ok := meta.Timers["build"]; ok {
+		t.Errorf("stale pending timer kept: %+v", meta.Timers["build"])
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("writes %v, want none", client.calls)
+	}
+}
+
+func TestRecoverTimersResumesPrevious(t *testing.T) {
+	useTempConfig(t)
+	client := sessionSheet(sessionRow("12/10/26", "general", "review", "", "2026-10-12T09:00:00Z", "default"))
+
+	// A switch saved the new timer but died before its row was appended.
+	meta := recoverWith(t, client, &internal.Meta{Timers: map[string]internal.Timer{
+		"default": {
+			Start: "2026-10-12T10:00:00Z", Bucket: "ops", Task: "deploy", State: internal.TimerPending,
+			Previous: &internal.Timer{Start: "2026-10-12T09:00:00Z", Bucket: "general", Task: "review", State: internal.TimerStopping, End: "2026-10-12T10:00:00Z"},
+		},
+	}})
+
+	want := internal.Timer{Start: "2026-10-12T09:00:00Z", Bucket: "general", Task: "review"}
+	if timer := meta.Timers["default"]; timer.Start != want.Start || timer.Task != want.Task || timer.State != "" || timer.End != "" || timer.Previous != nil {
+		t.Errorf("default = %+v, want %+v running again", timer, want)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("writes %v, want none", client.calls)
+	}
+}
+
+func TestRecoverTimersBooksPrevious(t *testing.T) {
+	useTempConfig(t)
+	// The switch appended its row but died before booking the session it replaced.
+	client := sessionSheet(
+		sessionRow("12/10/26", "general", "review", "", "2026-10-12T09:00:00Z", "default"),
+		sessionRow("12/10/26", "ops", "deploy", "", "2026-10-12T10:00:00Z", "default"),
+	)
+
+	meta := recoverWith(t, client, &internal.Meta{Timers: map[string]internal.Timer{
+		"default": {
+			Start: "2026-10-12T10:00:00Z", Bucket: "ops", Task: "deploy",
+			Previous: &internal.Timer{Start: "2026-10-12T09:00:00Z", Bucket: "general", Task: "review", State: internal.TimerStopping, End: "2026-10-12T10:00:00Z"},
+		},
+	}})
+
+	if timer := meta.Timers["default"]; timer.Task != "deploy" || timer.Previous != nil {
+		t.Errorf("default = %+v, want deploy running without a previous session", timer)
+	}
+	if got := client.cell(testSheet, 5, 5); got != "1.00" {
+		t.Errorf("review hours = %v, want 1.00", got)
+	}
+	if got := client.cell(testSheet, 6, 5); got != "" {
+		t.Errorf("deploy hours = %v, want it still running", got)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"bufio"
-	"context"
 	"fmt"
 	"log"
 	"os"
@@ -10,13 +9,15 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/srikanth-karthi/timesheet/internal"
-	"github.com/srikanth-karthi/timesheet/internal/setup"
-	"google.golang.org/api/option"
+	"github.com/srikanth-karthi/timesheet/internal/store"
 	"google.golang.org/api/sheets/v4"
 )
 
 var spreadsheetID = "1VWNJK55ytijKrR8QcOEr1JORUSWtlcoudkkTbpsUiYM"
-var createUser bool
+var (
+	createUser   bool
+	setupPromote bool
+)
 
 var setupCmd = &cobra.Command{
 	Use:   "setup",
@@ -33,51 +34,81 @@ var setupCmd = &cobra.Command{
 		password, _ := reader.ReadString('\n')
 		password = strings.TrimSpace(password)
 
-		ctx := context.Background()
-		provider := setup.GetCredentialProvider()
+		client := sheetsClient()
 
-		creds, err := provider.GetJSON()
+		err := ensureAdminSheet(client)
 		if err != nil {
-			log.Fatalf("  Failed to load credentials: %v", err)
+			log.Fatalf("  Failed to ensure admin sheet: %v", err)
 		}
 
-		srv, err := sheets.NewService(ctx, option.WithCredentialsJSON(creds))
-		if err != nil {
-			log.Fatalf("  Unable to create Sheets service: %v", err)
+		if err := ensureBillingSheets(client); err != nil {
+			log.Fatalf("  Failed to ensure billing sheets: %v", err)
 		}
 
-		if err != nil {
-			log.Fatalf("  Unable to create Sheets service: %v", err)
-		}
-
-		err = ensureAdminSheet(srv, spreadsheetID)
-		if err != nil {
-			log.Fatalf("  Failed to ensure admin sheet: %v", err)
+		if err := ensureCalendarSheet(client); err != nil {
+			log.Fatalf("  Failed to ensure calendar sheet: %v", err)
 		}
 
 		if createUser {
-			err := createUserInAdmin(srv, spreadsheetID, empID, password)
+			// Only the first user may register themselves, and becomes the
+			// admin who adds everyone else.
+			users, err := listUsers(client)
 			if err != nil {
+				log.Fatalf("  Failed to read users: %v", err)
+			}
+			if len(users) > 0 {
+				log.Fatalf("  Users already exist. Ask an admin to run 'timesheet admin users add %s'.", empID)
+			}
+			if err := createUserInAdmin(client, empID, password, roleAdmin, ""); err != nil {
 				log.Fatalf("  Failed to create user: %v", err)
 			}
+			if err := internal.SaveSession(empID, password); err != nil {
+				log.Fatalf("  Failed to save session: %v", err)
+			}
 			log.Printf("   User %s created successfully", empID)
 			log.Printf("Would you like to provide a project folder path? (y/n)")
-		
+
 			reader := bufio.NewReader(os.Stdin)
 			answer, err := reader.ReadString('\n')
 			if err != nil {
 				log.Fatalf("  Failed to read input: %v", err)
 			}
 			answer = strings.ToLower(strings.TrimSpace(answer))
-		
+
 			if answer != "yes" && answer != "y" {
-				fmt.Println("🚫 Aborting. Existing session still active.")
-				os.Exit(0)
+				return
+			}
+
+			wd, _ := os.Getwd()
+			fmt.Printf("📂 Project folder path [%s]: ", wd)
+			path, _ := reader.ReadString('\n')
+			path = strings.TrimSpace(path)
+			if path == "" {
+				path = wd
+			}
+			abs, err := resolveRepoPath(path)
+			if err != nil {
+				log.Fatalf("  Invalid path: %v", err)
+			}
+
+			fmt.Print("🧠 Bucket for this folder [general]: ")
+			bucket, _ := reader.ReadString('\n')
+			bucket = strings.TrimSpace(bucket)
+			if bucket == "" {
+				bucket = "general"
 			}
-	
-		
+
+			cfg, err := internal.LoadConfig()
+			if err != nil {
+				log.Fatalf("  Failed to read config: %v", err)
+			}
+			cfg.MapRepo(abs, bucket)
+			if err := internal.SaveConfig(cfg); err != nil {
+				log.Fatalf("  Failed to save config: %v", err)
+			}
+			log.Printf("📂 Mapped %s → %s", abs, bucket)
 		} else {
-			ok, err := validateCredentials(srv, spreadsheetID, empID, password)
+			ok, err := validateCredentials(client, empID, password)
 			if err != nil {
 				log.Fatalf("  Failed to validate login: %v", err)
 			}
@@ -85,64 +116,81 @@ var setupCmd = &cobra.Command{
 				log.Fatalf("  Invalid EMP ID or password")
 			}
 			log.Printf("   Welcome, %s!", empID)
+
+			users, err := loadAdminUsers(client)
+			if err != nil {
+				log.Fatalf("  Failed to read users: %v", err)
+			}
+			switch {
+			case setupPromote:
+				if err := claimAdmin(client, users, empID); err != nil {
+					log.Fatalf("  Cannot promote %s: %v", empID, err)
+				}
+				log.Printf("🛡️ %s is now the admin.", empID)
+			case !hasAdmin(users):
+				log.Printf("ℹ️ Nobody has the admin role yet. Run 'timesheet setup --promote' to claim it.")
+			}
 		}
-		
+
 	},
 }
 
-func ensureAdminSheet(srv *sheets.Service, spreadsheetID string) error {
-	ss, err := srv.Spreadsheets.Get(spreadsheetID).Do()
+var adminHeaders = []interface{}{"emp_id", "password", "role", "manager", "status"}
+
+// ensureAdminSheet creates the admin tab, or adds the role, manager and
+// status headers to one created before they existed.
+func ensureAdminSheet(client store.Client) error {
+	if err := ensureTab(client, "admin", adminHeaders); err != nil {
+		return err
+	}
+	resp, err := client.Get("admin!A1:E1")
+	if err != nil {
+		return err
+	}
+	if len(resp) > 0 && len(resp[0]) >= len(adminHeaders) {
+		return nil
+	}
+	return client.Update("admin!A1:E1", [][]interface{}{adminHeaders}, "RAW")
+}
+
+// ensureTab creates the tab with a single header row if it does not exist yet.
+func ensureTab(client store.Client, title string, headers []interface{}) error {
+	ss, err := client.Spreadsheet()
 	if err != nil {
 		return err
 	}
 
-	exists := false
 	for _, sheet := range ss.Sheets {
-		if sheet.Properties.Title == "admin" {
-			exists = true
-			break
+		if sheet.Properties.Title == title {
+			return nil
 		}
 	}
 
-	if !exists {
-		_, err := srv.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
-			Requests: []*sheets.Request{
-				{
-					AddSheet: &sheets.AddSheetRequest{
-						Properties: &sheets.SheetProperties{
-							Title: "admin",
-						},
+	_, err = client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				AddSheet: &sheets.AddSheetRequest{
+					Properties: &sheets.SheetProperties{
+						Title: title,
 					},
 				},
 			},
-		}).Do()
-		if err != nil {
-			return err
-		}
-
-		_, err = srv.Spreadsheets.Values.Update(spreadsheetID, "admin!A1:B1", &sheets.ValueRange{
-			Values: [][]interface{}{
-				{"emp_id", "password"},
-			},
-		}).ValueInputOption("RAW").Do()
-		if err != nil {
-			return err
-		}
-
-	} else {
-
+		},
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return client.Update(title+"!A1", [][]interface{}{headers}, "RAW")
 }
 
-func validateCredentials(srv *sheets.Service, spreadsheetID, empID, password string) (bool, error) {
-	resp, err := srv.Spreadsheets.Values.Get(spreadsheetID, "admin!A2:B").Do()
+func validateCredentials(client store.Client, empID, password string) (bool, error) {
+	resp, err := client.Get("admin!A2:E")
 	if err != nil {
 		return false, err
 	}
 
-	for _, row := range resp.Values {
+	for _, row := range resp {
 		if len(row) < 2 {
 			continue
 		}
@@ -150,7 +198,10 @@ func validateCredentials(srv *sheets.Service, spreadsheetID, empID, password str
 		sheetPass := fmt.Sprintf("%v", row[1])
 
 		if empID == sheetEmpID && password == sheetPass {
-			err = internal.SaveSession(empID)
+			if status := cellString(row, 4); status != "" && status != userActive {
+				return false, fmt.Errorf("account %s is %s", empID, status)
+			}
+			err = internal.SaveSession(empID, password)
 			if err != nil {
 				log.Fatalf("  Failed to save session: %v", err)
 			}
@@ -162,35 +213,31 @@ func validateCredentials(srv *sheets.Service, spreadsheetID, empID, password str
 	return false, nil
 }
 
-func createUserInAdmin(srv *sheets.Service, spreadsheetID, empID, password string) error {
-	exists, _ := validateCredentials(srv, spreadsheetID, empID, password)
-	if exists {
+// createUserInAdmin adds an active user to the admin tab and creates their
+// sheet.
+func createUserInAdmin(client store.Client, empID, password, role, manager string) error {
+	users, err := listUsers(client)
+	if err != nil {
+		return err
+	}
+	if containsString(users, empID) {
 		return fmt.Errorf("user already exists")
 	}
 
-	_, err := srv.Spreadsheets.Values.Append(spreadsheetID, "admin!A2:B",
-		&sheets.ValueRange{
-			Values: [][]interface{}{{empID, password}},
-		},
-	).ValueInputOption("RAW").Do()
+	err = client.Append("admin!A2:E", [][]interface{}{{empID, password, role, manager, userActive}}, "RAW")
 	if err != nil {
 		return err
 	}
 
 	userSheetName := empID
-	if err := ensureUserSheet(srv, spreadsheetID, userSheetName); err != nil {
+	if err := ensureUserSheet(client, userSheetName); err != nil {
 		return fmt.Errorf("failed to create user sheet: %v", err)
 	}
-	err = internal.SaveSession(empID)
-	if err != nil {
-		log.Fatalf("  Failed to save session: %v", err)
-	}
-
 	return nil
 }
 
-func ensureUserSheet(srv *sheets.Service, spreadsheetID, sheetName string) error {
-	ss, err := srv.Spreadsheets.Get(spreadsheetID).Do()
+func ensureUserSheet(client store.Client, sheetName string) error {
+	ss, err := client.Spreadsheet()
 	if err != nil {
 		return err
 	}
@@ -202,7 +249,7 @@ func ensureUserSheet(srv *sheets.Service, spreadsheetID, sheetName string) error
 		}
 	}
 
-	_, err = srv.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+	resp, err := client.BatchUpdateSpreadsheet(&sheets.BatchUpdateSpreadsheetRequest{
 		Requests: []*sheets.Request{
 			{
 				AddSheet: &sheets.AddSheetRequest{
@@ -212,21 +259,27 @@ func ensureUserSheet(srv *sheets.Service, spreadsheetID, sheetName string) error
 				},
 			},
 		},
-	}).Do()
+	})
 	if err != nil {
 		return err
 	}
-	_, err = srv.Spreadsheets.Values.Update(spreadsheetID, sheetName+"!A1:G2", &sheets.ValueRange{
-		Values: [][]any{
-			{"meta", "buckets", "general"},
-			{"date", "day", "project", "task_description", "hours", "timestamp"},
-		},
-	}).ValueInputOption("USER_ENTERED").Do()
+	err = client.Update(sheetName+"!A1:J2", [][]any{
+		{"meta", "buckets", "general"},
+		{"date", "day", "project", "task_description", "hours", "timestamp", "invoiced", "tags", "ticket", "timer"},
+	}, "USER_ENTERED")
 
 	if err != nil {
 		return err
 	}
 
+	for _, reply := range resp.Replies {
+		if reply.AddSheet != nil {
+			if err := formatUserSheet(client, reply.AddSheet.Properties.SheetId, nil); err != nil {
+				return err
+			}
+		}
+	}
+
 	log.Printf("   Created sheet '%s' with headers + meta.", sheetName)
 	return nil
 }
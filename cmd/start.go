@@ -5,16 +5,22 @@ import (
 
 	"fmt"
 	"github.com/spf13/cobra"
-	"github.com/srikanth-karthi/timesheet/internal/setup"
+	"github.com/srikanth-karthi/timesheet/internal"
 	"log"
 	"os"
 	"strings"
 	"time"
-	"google.golang.org/api/sheets/v4"
-	"github.com/srikanth-karthi/timesheet/internal"
 )
 
-var bucketFlag string
+var (
+	bucketFlag  string
+	startTags   []string
+	startTicket string
+	startID     string
+
+	startMessage  string
+	startContinue bool
+)
 
 var startCmd = &cobra.Command{
 	Use:   "start",
@@ -24,14 +30,24 @@ var startCmd = &cobra.Command{
 			fmt.Println("❌ Please run 'timesheet setup' first.")
 			os.Exit(1)
 		}
-		provider := setup.GetCredentialProvider()
-		srv := setup.GetSheetsService(provider)
+		client := getSheetsClient()
 
 		userSheet := internal.CurrentUserID
-		meta, _ := internal.LoadMeta()
+		meta := loadMeta()
+		recoverTimers(client, userSheet, meta)
+		ensureWeeksOpen(client, userSheet, time.Now().In(userLocation()))
 
-		if meta.SessionStart != "" {
-			fmt.Printf("⚠️  A session is already running (started at %s).\n", meta.SessionStart)
+		id := startID
+		if id == "" {
+			id = internal.DefaultTimer
+		}
+
+		if running, ok := meta.Timers[id]; ok {
+			if id == internal.DefaultTimer {
+				fmt.Printf("⚠️  A session is already running (started at %s).\n", running.Start)
+			} else {
+				fmt.Printf("⚠️  Timer '%s' is already running (started at %s).\n", id, running.Start)
+			}
 			fmt.Print("❓ Do you want to abandon it and start a new session? (yes/no): ")
 
 			reader := bufio.NewReader(os.Stdin)
@@ -43,56 +59,37 @@ var startCmd = &cobra.Command{
 				os.Exit(0)
 			}
 
-			oldStartTime, err := time.Parse(time.RFC3339, meta.SessionStart)
+			entries, err := fetchEntries(client, userSheet)
 			if err != nil {
-				log.Fatalf("❌ Invalid session_start time: %v", err)
+				log.Fatalf("❌ Failed to read timesheet rows: %v", err)
 			}
 
-			rowsResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!A5:G").Do()
+			hours := closeTimer(client, userSheet, entries, meta, id, time.Now(), false)
+			fmt.Printf("🕒 Previous session duration: %.2f hrs\n", hours)
+			fmt.Println("🗑️  Previous session ended and logged.")
+		}
+
+		repoBucket, taskHint := workingDirContext()
+
+		// --continue picks up the most recent entry's bucket, task, tags and
+		// ticket; explicit flags still win.
+		var previous *sheetEntry
+		if startContinue {
+			entries, err := fetchEntries(client, userSheet)
 			if err != nil {
 				log.Fatalf("❌ Failed to read timesheet rows: %v", err)
 			}
-
-			rowIndex := -1
-			for i, row := range rowsResp.Values {
-
-				if len(row) >= 6 {
-					tsRaw := fmt.Sprintf("%v", row[5])
-					parsedSheetTime, err1 := time.Parse(time.RFC3339, tsRaw)
-					parsedMetaTime, err2 := time.Parse(time.RFC3339, meta.SessionStart)
-					if err1 != nil || err2 != nil {
-						continue
-					}
-					if parsedSheetTime.Equal(parsedMetaTime) {
-						rowIndex = i + 3 // sheet starts from A3
-						break
-					}
-				}
-			}
-
-			if rowIndex != -1 {
-				now := time.Now()
-				duration := now.Sub(oldStartTime).Hours()
-				hours := fmt.Sprintf("%.2f", duration)
-
-				cellRef := fmt.Sprintf("E%d", rowIndex) // fixed column to E
-				_, err = srv.Spreadsheets.Values.Update(spreadsheetID, userSheet+"!"+cellRef, &sheets.ValueRange{
-					Values: [][]interface{}{{hours}},
-				}).ValueInputOption("USER_ENTERED").Do()
-				if err != nil {
-					log.Fatalf("❌ Failed to update hours: %v", err)
-				}
-				fmt.Printf("🕒 Previous session duration: %s hrs\n", hours)
-			} else {
-				fmt.Println("⚠️ Could not find previous session row to log hours.")
+			previous = latestEntry(entries)
+			if previous == nil {
+				log.Fatalf("❌ No previous entry to continue.")
 			}
-
-			meta.SessionStart = ""
-			_ = internal.SaveMeta(meta)
-			fmt.Println("🗑️  Previous session ended and logged.")
+			repoBucket = previous.Bucket
 		}
 
 		bucket := bucketFlag
+		if bucket == "" {
+			bucket = repoBucket
+		}
 		if bucket == "" {
 			bucket = meta.Active
 			if bucket == "" {
@@ -100,47 +97,61 @@ var startCmd = &cobra.Command{
 			}
 		}
 
-		bucketResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!C1:Z1").Do()
+		valid, err := bucketExists(client, userSheet, bucket)
 		if err != nil {
 			log.Fatalf("❌ Could not fetch buckets: %v", err)
 		}
-
-		valid := false
-		if len(bucketResp.Values) > 0 {
-			for _, cell := range bucketResp.Values[0] {
-				if cell.(string) == bucket {
-					valid = true
-					break
-				}
-			}
-		}
 		if !valid {
 			log.Fatalf("❌ Bucket '%s' is not valid. Use 'timesheet bucket' to view available ones.", bucket)
 		}
 
-		reader := bufio.NewReader(os.Stdin)
-		fmt.Print("📝 Task description: ")
-		desc, _ := reader.ReadString('\n')
-		desc = strings.TrimSpace(desc)
+		desc := strings.TrimSpace(startMessage)
+		if desc == "" && previous != nil {
+			desc = previous.Task
+		}
+		if desc == "" {
+			desc = readTaskDescription(client, userSheet, taskHint)
+		}
 
-		startTime := time.Now()
+		startTime := time.Now().In(userLocation())
 		startTimeRFC := startTime.Format(time.RFC3339)
 		formattedDate := startTime.Format("02/01/06") // dd/mm/yy
 		day := startTime.Format("Monday")             // Weekday
 
-		meta.SessionStart = startTimeRFC
-		_ = internal.SaveMeta(meta)
+		// The timer stays pending until its row is in the sheet; if this
+		// command dies in between, the next one settles it.
+		release := lockTimers()
+		defer release()
+		updateMeta(meta, startTimer(id, internal.Timer{Start: startTimeRFC, Bucket: bucket, Task: desc, State: internal.TimerPending}))
+
+		tags := internal.NormalizeTags(append(startTags, internal.ParseTags(desc)...))
+		if previous != nil {
+			tags = internal.NormalizeTags(append(tags, previous.Tags...))
+		}
+
+		ticket := internal.NormalizeTicket(startTicket)
+		if ticket == "" && previous != nil {
+			ticket = previous.Ticket
+		}
+		if ticket == "" {
+			ticket = detectTicket(desc)
+		}
 
-		_, err = srv.Spreadsheets.Values.Append(spreadsheetID, userSheet+"!A3:G", &sheets.ValueRange{
-			Values: [][]interface{}{
-				{formattedDate, day, bucket, desc, "", startTimeRFC},
-			},
-		}).ValueInputOption("USER_ENTERED").InsertDataOption("INSERT_ROWS").Do()
+		err = client.Append(userSheet+"!A3:J", [][]interface{}{
+			{formattedDate, day, bucket, desc, "", startTimeRFC, "", internal.JoinTags(tags), ticket, id},
+		}, "USER_ENTERED")
 
 		if err != nil {
-			log.Fatalf("❌ Failed to log new task: %v", err)
+			log.Fatalf("❌ Failed to log new task: %v\n   The timer is checked against the sheet on the next command.", err)
 		}
+		updateMeta(meta, commitTimer(id))
+		release()
 
-		fmt.Printf("⏱️  Started tracking task: '%s' in bucket '%s'\n", desc, bucket)
+		if id == internal.DefaultTimer {
+			fmt.Printf("⏱️  Started tracking task: '%s' in bucket '%s'\n", desc, bucket)
+		} else {
+			fmt.Printf("⏱️  Started timer '%s' tracking task: '%s' in bucket '%s'\n", id, desc, bucket)
+		}
+		budgetWarnings(client, bucket, 0, time.Now())
 	},
 }
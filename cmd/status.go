@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "🚦 Show the active bucket and running timers",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		meta := loadMeta()
+		recoverTimers(client, internal.CurrentUserID, meta)
+		fmt.Printf("👤 %s  🧠 bucket: %s\n", internal.CurrentUserID, meta.Active)
+
+		if sub, err := findSubmission(client, internal.CurrentUserID, weekOf("")); err == nil && sub != nil {
+			fmt.Printf("📤 This week: %s (%s)\n", sub.Status, sub.UpdatedAt)
+		}
+		if rejections, err := openRejections(client, internal.CurrentUserID); err == nil {
+			for _, r := range rejections {
+				fmt.Printf("↩️  Week of %s was rejected by %s: %s\n", r.Week, r.Approver, r.Reason)
+			}
+		}
+
+		if len(meta.Timers) == 0 {
+			fmt.Println("⏸️  No timers running.")
+			return
+		}
+
+		loc := userLocation()
+		now := time.Now()
+		fmt.Println("⏱️  Running timers:")
+		for _, id := range meta.TimerIDs() {
+			timer := meta.Timers[id]
+			start, err := time.Parse(time.RFC3339, timer.Start)
+			if err != nil {
+				fmt.Printf("  - %-10s invalid start time %q\n", id, timer.Start)
+				continue
+			}
+			fmt.Printf("  - %-10s %-10s %-30s since %s (%.2f hrs)\n", id, timer.Bucket, timer.Task, start.In(loc).Format("Mon 15:04"), now.Sub(start).Hours())
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err == nil && len(meta.Timers) > 1 && cfg.ParallelPolicy() == internal.ParallelSplit {
+			fmt.Println("ℹ️ Parallel time is shared between timers in reports (parallel-time = split).")
+		}
+	},
+}
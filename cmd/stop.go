@@ -4,76 +4,97 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/srikanth-karthi/timesheet/internal"
-	"github.com/srikanth-karthi/timesheet/internal/setup"
-	"google.golang.org/api/sheets/v4"
 )
 
+var stopAll bool
+
 var stopCmd = &cobra.Command{
-	Use:   "stop",
+	Use:   "stop [id]",
 	Short: "⏹️ Stop tracking the current session and log the duration",
+	Long: `Stop a running timer and log its duration.
+
+Without an id the only running timer (or the default one) is stopped. Use
+--all to stop every running timer at once.`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		meta, err := internal.LoadMeta()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return meta.TimerIDs(), cobra.ShellCompDirectiveNoFileComp
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if !internal.IsLoggedIn() {
 			fmt.Println("  Please run 'timesheet setup' first.")
 			os.Exit(1)
 		}
 
-		meta, _ := internal.LoadMeta()
-		if meta.SessionStart == "" {
+		client := getSheetsClient()
+		userSheet := internal.CurrentUserID
+
+		meta := loadMeta()
+		recoverTimers(client, userSheet, meta)
+		if len(meta.Timers) == 0 {
 			fmt.Println("⚠️ No session is currently running.")
 			return
 		}
 
-		oldStartTime, err := time.Parse(time.RFC3339, meta.SessionStart)
-		if err != nil {
-			log.Fatalf("  Invalid session_start time: %v", err)
+		var ids []string
+		switch {
+		case stopAll:
+			ids = meta.TimerIDs()
+		case len(args) == 1:
+			if _, ok := meta.Timers[args[0]]; !ok {
+				fmt.Printf("⚠️ No timer named '%s' is running.\n", args[0])
+				os.Exit(1)
+			}
+			ids = args
+		case len(meta.Timers) == 1:
+			ids = meta.TimerIDs()
+		default:
+			if _, ok := meta.Timers[internal.DefaultTimer]; !ok {
+				fmt.Printf("⚠️ Several timers are running (%s). Name one or use --all.\n", strings.Join(meta.TimerIDs(), ", "))
+				os.Exit(1)
+			}
+			ids = []string{internal.DefaultTimer}
 		}
 
-		provider := setup.GetCredentialProvider()
-		srv := setup.GetSheetsService(provider)
-
-		userSheet := internal.CurrentUserID
-
-		rowsResp, err := srv.Spreadsheets.Values.Get(spreadsheetID, userSheet+"!A5:G").Do()
+		entries, err := fetchEntries(client, userSheet)
 		if err != nil {
 			log.Fatalf("  Failed to read timesheet rows: %v", err)
 		}
 
-		rowIndex := -1
-		for i, row := range rowsResp.Values {
-			if len(row) >= 6 {
-				ts := fmt.Sprintf("%v", row[5])
-				if ts == meta.SessionStart {
-					rowIndex = i + 5 // adjust for A5:G offset
-					break
-				}
+		now := time.Now()
+		var buckets []string
+		logged := map[string]float64{}
+		for _, id := range ids {
+			if len(ids) > 1 || id != internal.DefaultTimer {
+				fmt.Printf("⏹️  Timer '%s':\n", id)
+			}
+			timer := meta.Timers[id]
+			bucket := timer.Bucket
+			if entry := findSessionEntry(entries, timer.Start, id); bucket == "" && entry != nil {
+				bucket = entry.Bucket
 			}
-		}
-
-		if rowIndex != -1 {
-			now := time.Now()
-			duration := now.Sub(oldStartTime).Hours()
-			hours := fmt.Sprintf("%.2f", duration)
-
-			cellRef := fmt.Sprintf("E%d", rowIndex) // hours column
-			_, err = srv.Spreadsheets.Values.Update(spreadsheetID, userSheet+"!"+cellRef, &sheets.ValueRange{
-				Values: [][]interface{}{{hours}},
-			}).ValueInputOption("USER_ENTERED").Do()
 
-			if err != nil {
-				log.Fatalf("  Failed to update hours: %v", err)
+			hours := closeTimer(client, userSheet, entries, meta, id, now, true)
+			if hours > 0 {
+				fmt.Printf("🕒 Session stopped. Duration: %.2f hrs logged\n", hours)
+				if bucket != "" && !containsString(buckets, bucket) {
+					buckets = append(buckets, bucket)
+				}
+				logged[bucket] += hours
 			}
-			fmt.Printf("🕒 Session stopped. Duration: %s hrs logged in row %d\n", hours, rowIndex)
-		} else {
-			fmt.Println("⚠️ Could not find previous session row to log hours.")
 		}
-
-		meta.SessionStart = ""
-		_ = internal.SaveMeta(meta)
 		fmt.Println("   Session cleared.")
+		for _, bucket := range buckets {
+			budgetWarnings(client, bucket, logged[bucket], now)
+		}
 	},
 }
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Submitted weeks are tracked in a shared tab, one row per user and week:
+//
+//	submissions: emp_id | week (Monday, yyyy-mm-dd) | status | updated_at | updated_by
+//
+// A submitted or approved week is locked: nothing writes entries dated in
+// it until an admin reopens it or the manager rejects it.
+const (
+	submissionsSheet = "submissions"
+
+	statusSubmitted = "submitted"
+	statusReopened  = "reopened"
+	statusApproved  = "approved"
+	statusRejected  = "rejected"
+)
+
+var (
+	submitWeek bool
+	submitDate string
+	reopenUser string
+	reopenDate string
+)
+
+type submission struct {
+	Row       int
+	EmpID     string
+	Week      string
+	Status    string
+	UpdatedAt string
+	UpdatedBy string
+}
+
+func (s submission) locked() bool {
+	return s.Status == statusSubmitted || s.Status == statusApproved
+}
+
+var submitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "📤 Submit a week, locking its entries",
+	Long: `Submit the current week (or the week containing --date) for sign-off.
+
+Once submitted, 'log', 'start', 'stop', 'switch' and the other commands that
+write entries refuse to touch dates in that week until an admin runs
+'timesheet reopen'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+		monday := weekOf(submitDate)
+		sunday := monday.AddDate(0, 0, 6)
+		empID := internal.CurrentUserID
+		client := getSheetsClient()
+
+		sub, err := findSubmission(client, empID, monday)
+		if err != nil {
+			log.Fatalf("  Failed to read submissions: %v", err)
+		}
+		if sub != nil && sub.locked() {
+			fmt.Printf("ℹ️ Week of %s is already %s.\n", monday.Format("2006-01-02"), sub.Status)
+			return
+		}
+
+		meta := loadMeta()
+		for id, timer := range meta.Timers {
+			start, err := time.Parse(time.RFC3339, timer.Start)
+			if err == nil && !dateOf(start).Before(monday) && !dateOf(start).After(sunday) {
+				log.Fatalf("  Timer '%s' is still running in that week. Stop it first.", id)
+			}
+		}
+
+		entries, err := fetchEntries(client, empID)
+		if err != nil {
+			log.Fatalf("  Failed to read timesheet rows: %v", err)
+		}
+		total := 0.0
+		count := 0
+		for _, e := range entries {
+			if !e.Date.Before(monday) && !e.Date.After(sunday) {
+				total += e.Hours
+				count++
+			}
+		}
+
+		if err := setSubmission(client, empID, monday, statusSubmitted, empID); err != nil {
+			log.Fatalf("  Failed to submit week: %v", err)
+		}
+		fmt.Printf("📤 Submitted week of %s (%d entries, %.1f hrs). It is now locked.\n", monday.Format("2006-01-02"), count, total)
+	},
+}
+
+var reopenCmd = &cobra.Command{
+	Use:   "reopen",
+	Short: "🔓 Reopen a submitted week (admin only)",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		requireRole(client, internal.CurrentUserID, roleAdmin)
+
+		empID := reopenUser
+		if empID == "" {
+			empID = internal.CurrentUserID
+		}
+		monday := weekOf(reopenDate)
+
+		sub, err := findSubmission(client, empID, monday)
+		if err != nil {
+			log.Fatalf("  Failed to read submissions: %v", err)
+		}
+		if sub == nil || !sub.locked() {
+			fmt.Printf("ℹ️ Week of %s is not locked for %s.\n", monday.Format("2006-01-02"), empID)
+			return
+		}
+
+		if err := setSubmission(client, empID, monday, statusReopened, internal.CurrentUserID); err != nil {
+			log.Fatalf("  Failed to reopen week: %v", err)
+		}
+		fmt.Printf("🔓 Reopened week of %s for %s.\n", monday.Format("2006-01-02"), empID)
+	},
+}
+
+// weekOf returns the Monday (as a UTC date) of the week containing date
+// (yyyy-mm-dd or dd/mm/yy), or of the current week when date is empty.
+func weekOf(date string) time.Time {
+	day := dateOf(time.Now().In(userLocation()))
+	if date != "" {
+		parsed, err := parseDay(date, time.UTC)
+		if err != nil {
+			log.Fatalf("  %v", err)
+		}
+		day = parsed
+	}
+	return weekDays(day)[0]
+}
+
+// dateOf drops the clock and zone of t, matching how entry dates are read.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func ensureSubmissionsSheet(client store.Client) error {
+	return ensureTab(client, submissionsSheet, []interface{}{"emp_id", "week", "status", "updated_at", "updated_by"})
+}
+
+func loadSubmissions(client store.Client) ([]submission, error) {
+	found, err := tabExists(client, submissionsSheet)
+	if err != nil || !found {
+		return nil, err
+	}
+	resp, err := client.Get(submissionsSheet + "!A2:E")
+	if err != nil {
+		return nil, err
+	}
+
+	var subs []submission
+	for i, row := range resp {
+		if cellString(row, 0) == "" {
+			continue
+		}
+		subs = append(subs, submission{
+			Row:       i + 2,
+			EmpID:     cellString(row, 0),
+			Week:      cellString(row, 1),
+			Status:    cellString(row, 2),
+			UpdatedAt: cellString(row, 3),
+			UpdatedBy: cellString(row, 4),
+		})
+	}
+	return subs, nil
+}
+
+func findSubmission(client store.Client, empID string, monday time.Time) (*submission, error) {
+	subs, err := loadSubmissions(client)
+	if err != nil {
+		return nil, err
+	}
+	week := monday.Format("2006-01-02")
+	for i := range subs {
+		if subs[i].EmpID == empID && subs[i].Week == week {
+			return &subs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// setSubmission records status for empID's week, updating its row or
+// adding one.
+func setSubmission(client store.Client, empID string, monday time.Time, status, by string) error {
+	if err := ensureSubmissionsSheet(client); err != nil {
+		return err
+	}
+	sub, err := findSubmission(client, empID, monday)
+	if err != nil {
+		return err
+	}
+
+	values := [][]interface{}{{empID, monday.Format("2006-01-02"), status, time.Now().Format(time.RFC3339), by}}
+	if sub != nil {
+		return client.Update(fmt.Sprintf("%s!A%d:E%d", submissionsSheet, sub.Row, sub.Row), values, "RAW")
+	}
+	return client.Append(submissionsSheet+"!A:E", values, "RAW")
+}
+
+// ensureWeeksOpen exits if any of the days falls in a locked week of empID.
+func ensureWeeksOpen(client store.Client, empID string, days ...time.Time) {
+	subs, err := loadSubmissions(client)
+	if err != nil {
+		log.Fatalf("  Failed to read submissions: %v", err)
+	}
+	for _, day := range days {
+		week := weekDays(dateOf(day))[0].Format("2006-01-02")
+		for _, s := range subs {
+			if s.EmpID == empID && s.Week == week && s.locked() {
+				log.Fatalf("  The week of %s is %s and locked. Ask an admin to 'timesheet reopen' it.", week, s.Status)
+			}
+		}
+	}
+}
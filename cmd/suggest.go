@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+var (
+	suggestFrom   string
+	suggestTo     string
+	suggestAuthor string
+	suggestGap    time.Duration
+	suggestLead   time.Duration
+)
+
+const maxSuggestedTaskLen = 120
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "💡 Draft entries from your git commits in mapped repositories",
+	Long: `Scan the repositories mapped with 'timesheet repo add' for your commits,
+group them into work sessions and propose one entry per session. Each
+proposal can be accepted, edited or dropped; accepted entries are appended
+in a single batch.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		loc := userLocation()
+		today := time.Now().In(loc)
+		from := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
+		if suggestFrom != "" {
+			parsed, err := time.ParseInLocation("02/01/06", suggestFrom, loc)
+			if err != nil {
+				log.Fatalf("  Invalid --from date. Use dd/mm/yy")
+			}
+			from = parsed
+		}
+		to := from
+		if suggestTo != "" {
+			parsed, err := time.ParseInLocation("02/01/06", suggestTo, loc)
+			if err != nil {
+				log.Fatalf("  Invalid --to date. Use dd/mm/yy")
+			}
+			to = parsed
+		}
+		to = to.AddDate(0, 0, 1).Add(-time.Second)
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		if len(cfg.Repos) == 0 {
+			fmt.Println("ℹ️ No repositories mapped. Use 'timesheet repo add --bucket <name>' first.")
+			return
+		}
+
+		buckets := map[string]string{}
+		var commits []internal.Commit
+		for _, r := range cfg.Repos {
+			buckets[r.Path] = r.Bucket
+			author := suggestAuthor
+			if author == "" {
+				author = internal.GitAuthorEmail(r.Path)
+			}
+			if author == "" {
+				log.Printf("⚠️ Skipping %s: no git user.email configured (use --author)", r.Path)
+				continue
+			}
+			repoCommits, err := internal.ReadCommits(r.Path, author, from, to)
+			if err != nil {
+				log.Printf("⚠️ Skipping %s: %v", r.Path, err)
+				continue
+			}
+			commits = append(commits, repoCommits...)
+		}
+
+		sessions := internal.ClusterSessions(commits, suggestGap, suggestLead)
+		if len(sessions) == 0 {
+			fmt.Printf("ℹ️ No commits found between %s and %s.\n", from.Format("02/01/06"), to.Format("02/01/06"))
+			return
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		var rows [][]interface{}
+		var days []time.Time
+	review:
+		for i, s := range sessions {
+			bucket := buckets[s.Repo]
+			task := summarizeSubjects(s.Subjects)
+			hours := strconv.FormatFloat(s.Hours, 'f', 2, 64)
+			start, end := s.Start.In(loc), s.End.In(loc)
+
+			fmt.Printf("\n💡 [%d/%d] %s %s–%s  %s\n", i+1, len(sessions), start.Format("Mon 02/01/06"), start.Format("15:04"), end.Format("15:04"), s.Repo)
+			fmt.Printf("   %-10s → %s → %s hrs\n", bucket, task, hours)
+
+		ask:
+			for {
+				fmt.Print("❓ [a]ccept, [e]dit, [d]rop, [q]uit (a): ")
+				answer, _ := reader.ReadString('\n')
+				switch strings.ToLower(strings.TrimSpace(answer)) {
+				case "", "a", "accept":
+					break ask
+				case "e", "edit":
+					bucket = promptDefault(reader, "   Bucket", bucket)
+					task = promptDefault(reader, "   Task", task)
+					hours = promptDefault(reader, "   Hours", hours)
+					break ask
+				case "d", "drop":
+					continue review
+				case "q", "quit":
+					fmt.Println("🚫 Stopped reviewing suggestions.")
+					break review
+				default:
+					fmt.Println("   Please answer a, e, d or q.")
+				}
+			}
+
+			rows = append(rows, []interface{}{
+				start.Format("02/01/06"), start.Format("Monday"), bucket, task, hours,
+				start.Format(time.RFC3339), "", internal.JoinTags(internal.ParseTags(task)), detectTicket(task),
+			})
+			days = append(days, start)
+		}
+
+		if len(rows) == 0 {
+			fmt.Println("ℹ️ Nothing to log.")
+			return
+		}
+
+		client := getSheetsClient()
+		ensureWeeksOpen(client, internal.CurrentUserID, days...)
+		err = client.Append(internal.CurrentUserID+"!A3:J", rows, "USER_ENTERED")
+		if err != nil {
+			log.Fatalf("  Failed to log suggested entries: %v", err)
+		}
+
+		fmt.Printf("\n   Logged %d suggested entries.\n", len(rows))
+	},
+}
+
+func promptDefault(reader *bufio.Reader, label, def string) string {
+	fmt.Printf("%s [%s]: ", label, def)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def
+	}
+	return answer
+}
+
+// summarizeSubjects joins distinct commit subjects into one task description.
+func summarizeSubjects(subjects []string) string {
+	seen := map[string]bool{}
+	var parts []string
+	for _, s := range subjects {
+		if !seen[s] {
+			seen[s] = true
+			parts = append(parts, s)
+		}
+	}
+	task := strings.Join(parts, "; ")
+	if runes := []rune(task); len(runes) > maxSuggestedTaskLen {
+		task = string(runes[:maxSuggestedTaskLen-1]) + "…"
+	}
+	return task
+}
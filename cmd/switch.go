@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+var (
+	switchMessage string
+	switchID      string
+	switchTags    []string
+	switchTicket  string
+)
+
+var switchCmd = &cobra.Command{
+	Use:   "switch [bucket]",
+	Short: "🔀 Close the running session and start a new one at the same instant",
+	Long: `Switch to a new task without losing or double-counting time.
+
+The running timer is stopped and the new one started at the very same
+instant. Without a bucket the new session stays in the bucket of the one
+being closed; with a bucket it also becomes the active bucket.
+
+The old session is only closed once the new row is written; if that fails,
+the old session keeps running.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBuckets,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		client := getSheetsClient()
+		userSheet := internal.CurrentUserID
+		meta := loadMeta()
+		recoverTimers(client, userSheet, meta)
+
+		id := switchID
+		if id == "" {
+			id = internal.DefaultTimer
+		}
+		previous, running := meta.Timers[id]
+
+		repoBucket, taskHint := workingDirContext()
+
+		bucket := ""
+		if len(args) == 1 {
+			bucket = args[0]
+		}
+		if bucket == "" && running {
+			bucket = previous.Bucket
+		}
+		if bucket == "" {
+			bucket = repoBucket
+		}
+		if bucket == "" {
+			bucket = meta.Active
+		}
+		if bucket == "" {
+			log.Fatalf("  No active bucket found. Use 'timesheet bucket <name>' to set one.")
+		}
+
+		valid, err := bucketExists(client, userSheet, bucket)
+		if err != nil {
+			log.Fatalf("  Could not fetch buckets: %v", err)
+		}
+		if !valid {
+			log.Fatalf("  Bucket '%s' is not valid. Use 'timesheet bucket' to view available ones.", bucket)
+		}
+
+		desc := strings.TrimSpace(switchMessage)
+		if desc == "" {
+			desc = readTaskDescription(client, userSheet, taskHint)
+		}
+
+		// Both rows share this instant: the old one ends and the new one
+		// starts here.
+		now := time.Now().In(userLocation())
+		ensureWeeksOpen(client, userSheet, now)
+
+		startRFC := now.Format(time.RFC3339)
+		tags := internal.NormalizeTags(append(switchTags, internal.ParseTags(desc)...))
+		ticket := internal.NormalizeTicket(switchTicket)
+		if ticket == "" {
+			ticket = detectTicket(desc)
+		}
+
+		next := internal.Timer{Start: startRFC, Bucket: bucket, Task: desc}
+		row := []interface{}{now.Format("02/01/06"), now.Format("Monday"), bucket, desc, "", startRFC, "", internal.JoinTags(tags), ticket, id}
+		switchSession(client, userSheet, meta, id, next, row, len(args) == 1)
+
+		fmt.Printf("⏱️  Now tracking '%s' in bucket '%s'\n", desc, bucket)
+		budgetWarnings(client, bucket, 0, now)
+	},
+}
+
+// switchSession replaces timer id, if running, with next at the instant
+// next starts; row is next's row in the sheet. The closed session is kept
+// in meta as next's Previous and only booked once the new row is written,
+// so a failed append leaves it running rather than losing its time. With
+// activate, next's bucket becomes the active one.
+func switchSession(client store.Client, userSheet string, meta *internal.Meta, id string, next internal.Timer, row []interface{}, activate bool) {
+	now, err := time.Parse(time.RFC3339, next.Start)
+	if err != nil {
+		log.Fatalf("  Invalid start time for timer '%s': %v", id, err)
+	}
+	previous, running := meta.Timers[id]
+
+	release := lockTimers()
+	defer release()
+
+	var entries []sheetEntry
+	var closing *internal.Timer
+	if running {
+		entries, err = fetchEntries(client, userSheet)
+		if err != nil {
+			log.Fatalf("  Failed to read timesheet rows: %v", err)
+		}
+		finishSwitch(client, userSheet, entries, meta, id)
+		start, err := time.Parse(time.RFC3339, previous.Start)
+		if err != nil {
+			log.Fatalf("  Invalid start time for timer '%s': %v", id, err)
+		}
+		ensureWeeksOpen(client, userSheet, start.In(userLocation()))
+		if canBook(entries, id, previous) {
+			spans := sessionSpans(start, now, true)
+			closing = &previous
+			closing.State = internal.TimerStopping
+			closing.End = spans[len(spans)-1].End.Format(time.RFC3339)
+		}
+	} else {
+		fmt.Println("ℹ️ No session was running; starting a new one.")
+	}
+
+	updateMeta(meta, func(m *internal.Meta) error {
+		current, ok := m.Timers[id]
+		if running && (!ok || current.Start != previous.Start) {
+			return fmt.Errorf("timer '%s' was changed by another command", id)
+		}
+		if !running && ok {
+			return fmt.Errorf("timer '%s' was started by another command at %s", id, current.Start)
+		}
+		next.State, next.Previous = internal.TimerPending, closing
+		m.StartTimer(id, next)
+		if activate {
+			m.Active = next.Bucket
+		}
+		return nil
+	})
+
+	err = client.Append(userSheet+"!A3:J", [][]interface{}{row}, "USER_ENTERED")
+	if err != nil {
+		log.Fatalf("  Failed to log new task: %v\n   The timer is checked against the sheet on the next command.", err)
+	}
+	updateMeta(meta, commitTimer(id))
+
+	if closing != nil {
+		hours, err := bookTimer(client, userSheet, entries, id, *closing)
+		if err != nil {
+			log.Fatalf("  Failed to close '%s': %v\n   It is closed on the next command.", previous.Task, err)
+		}
+		updateMeta(meta, clearPrevious(id))
+		fmt.Printf("⏹️  Closed '%s' in '%s': %.2f hrs logged\n", previous.Task, previous.Bucket, hours)
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// metaOnAppend records meta.json as it was when each row was appended.
+type metaOnAppend struct {
+	*fakeSheets
+	t     *testing.T
+	saved []*internal.Meta
+}
+
+func (m *metaOnAppend) Append(rng string, rows [][]interface{}, inputOption string) error {
+	meta, err := internal.LoadMeta()
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	m.saved = append(m.saved, meta)
+	return m.fakeSheets.Append(rng, rows, inputOption)
+}
+
+func TestSwitchSessionWritesNextRowFirst(t *testing.T) {
+	useTempConfig(t)
+	client := &metaOnAppend{
+		fakeSheets: sessionSheet(sessionRow("12/10/26", "general", "review", "", "2026-10-12T09:00:00Z", "default")),
+		t:          t,
+	}
+	running := internal.Timer{Start: "2026-10-12T09:00:00Z", Bucket: "general", Task: "review"}
+	meta := &internal.Meta{Active: "general", Timers: map[string]internal.Timer{"default": running}}
+	if err := internal.SaveMeta(meta); err != nil {
+		t.Fatal(err)
+	}
+
+	next := internal.Timer{Start: "2026-10-12T10:30:00Z", Bucket: "ops", Task: "deploy"}
+	row := sessionRow("12/10/26", "ops", "deploy", "", next.Start, "default")
+	switchSession(client, testSheet, meta, "default", next, row, true)
+
+	want := []string{"append " + testSheet + "!A3:J", "update " + testSheet + "!E5"}
+	if !reflect.DeepEqual(client.calls, want) {
+		t.Fatalf("writes %v, want %v", client.calls, want)
+	}
+
+	// While the new row was written, the old session was still in meta,
+	// waiting to be booked.
+	pending := client.saved[0].Timers["default"]
+	if pending.State != internal.TimerPending || pending.Previous == nil {
+		t.Fatalf("timer while appending = %+v, want pending with the previous session", pending)
+	}
+	if previous := *pending.Previous; previous.State != internal.TimerStopping || previous.End != next.Start {
+		t.Errorf("previous session = %+v, want stopping at %s", previous, next.Start)
+	}
+
+	if got := client.cell(testSheet, 5, 5); got != "1.50" {
+		t.Errorf("review hours = %v, want 1.50", got)
+	}
+	saved, err := internal.LoadMeta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timer := saved.Timers["default"]; !reflect.DeepEqual(timer, next) {
+		t.Errorf("timer = %+v, want %+v", timer, next)
+	}
+	if saved.Active != "ops" {
+		t.Errorf("active = %q, want ops", saved.Active)
+	}
+}
+
+func TestSwitchSessionNothingRunning(t *testing.T) {
+	useTempConfig(t)
+	client := sessionSheet()
+	meta := &internal.Meta{Active: "general"}
+	if err := internal.SaveMeta(meta); err != nil {
+		t.Fatal(err)
+	}
+
+	next := internal.Timer{Start: "2026-10-12T10:30:00Z", Bucket: "ops", Task: "deploy"}
+	switchSession(client, testSheet, meta, "default", next, sessionRow("12/10/26", "ops", "deploy", "", next.Start, "default"), false)
+
+	if want := []string{"append " + testSheet + "!A3:J"}; !reflect.DeepEqual(client.calls, want) {
+		t.Errorf("writes %v, want %v", client.calls, want)
+	}
+	saved, err := internal.LoadMeta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timer := saved.Timers["default"]; !reflect.DeepEqual(timer, next) {
+		t.Errorf("timer = %+v, want %+v", timer, next)
+	}
+	if saved.Active != "general" {
+		t.Errorf("active = %q, want general", saved.Active)
+	}
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// Contracted hours set by an admin live in a shared tab and win over the
+// user's local settings:
+//
+//	targets: emp_id | hours_per_day | hours_per_week | work_days
+const targetsSheet = "targets"
+
+var (
+	targetUser   string
+	targetDaily  float64
+	targetWeekly float64
+	targetDays   string
+)
+
+var targetCmd = &cobra.Command{
+	Use:   "target",
+	Short: "🎯 Show or set contracted hours used for overtime",
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		cfg, source := loadContract(getSheetsClient(), internal.CurrentUserID)
+		days := cfg.WorkDays
+		if days == "" {
+			days = "mon-fri"
+		}
+		fmt.Printf("🎯 Target for %s (%s):\n", internal.CurrentUserID, source)
+		fmt.Printf("  hours per day  : %.2f\n", cfg.DailyHours())
+		if cfg.HoursPerWeek > 0 {
+			fmt.Printf("  hours per week : %.2f\n", cfg.HoursPerWeek)
+		}
+		fmt.Printf("  work days      : %s\n", days)
+		fmt.Printf("  weekend work   : %s\n", cfg.WeekendPolicy())
+		fmt.Printf("  holidays       : %s\n", cfg.HolidayPolicy())
+		if cfg.OvertimeSince != "" {
+			fmt.Printf("  balance since  : %s\n", cfg.OvertimeSince)
+		}
+	},
+}
+
+var targetSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set contracted hours, locally or (admins) for a user in the shared targets tab",
+	Long: `Set contracted hours.
+
+Without --user the values are saved in your local config. With --user they
+are written to the shared "targets" tab, where they override that user's
+local settings; this needs the admin role, also for your own row.
+
+Examples:
+  timesheet target set --weekly 40 --days mon-fri
+  timesheet target set --user E042 --daily 6 --days mon-thu`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+		if targetDays != "" {
+			if _, err := internal.ParseWeekdays(targetDays); err != nil {
+				log.Fatalf("  Invalid --days: %v", err)
+			}
+		}
+
+		if targetUser == "" {
+			cfg, err := internal.LoadConfig()
+			if err != nil {
+				log.Fatalf("  Failed to read config: %v", err)
+			}
+			if cmd.Flags().Changed("daily") {
+				cfg.HoursPerDay = targetDaily
+			}
+			if cmd.Flags().Changed("weekly") {
+				cfg.HoursPerWeek = targetWeekly
+			}
+			if targetDays != "" {
+				cfg.WorkDays = targetDays
+			}
+			if err := internal.SaveConfig(cfg); err != nil {
+				log.Fatalf("  Failed to save config: %v", err)
+			}
+			fmt.Printf("🎯 Target: %.2f hrs/day on %s\n", cfg.DailyHours(), cfg.WorkDays)
+			return
+		}
+
+		client := getSheetsClient()
+		requireRole(client, internal.CurrentUserID, roleAdmin)
+		if err := ensureTab(client, targetsSheet, []interface{}{"emp_id", "hours_per_day", "hours_per_week", "work_days"}); err != nil {
+			log.Fatalf("  Failed to ensure targets sheet: %v", err)
+		}
+		resp, err := client.Get(targetsSheet + "!A2:D")
+		if err != nil {
+			log.Fatalf("  Failed to read targets sheet: %v", err)
+		}
+
+		row := []interface{}{targetUser, "", "", ""}
+		rowNum := 0
+		for i, r := range resp {
+			if cellString(r, 0) == targetUser {
+				row = []interface{}{targetUser, cellString(r, 1), cellString(r, 2), cellString(r, 3)}
+				rowNum = i + 2
+				break
+			}
+		}
+		if cmd.Flags().Changed("daily") {
+			row[1] = strconv.FormatFloat(targetDaily, 'f', -1, 64)
+		}
+		if cmd.Flags().Changed("weekly") {
+			row[2] = strconv.FormatFloat(targetWeekly, 'f', -1, 64)
+		}
+		if targetDays != "" {
+			row[3] = targetDays
+		}
+
+		if rowNum > 0 {
+			err = client.Update(fmt.Sprintf("%s!A%d:D%d", targetsSheet, rowNum, rowNum), [][]interface{}{row}, "RAW")
+		} else {
+			err = client.Append(targetsSheet+"!A:D", [][]interface{}{row}, "RAW")
+		}
+		if err != nil {
+			log.Fatalf("  Failed to save target: %v", err)
+		}
+		fmt.Printf("🎯 Target for %s saved: %v hrs/day, %v hrs/week, %v\n", targetUser, row[1], row[2], row[3])
+	},
+}
+
+// loadContract returns the local config with the user's row from the
+// targets tab applied on top, and where the hours came from. Other users
+// start from the defaults since their local settings are not visible.
+func loadContract(client store.Client, empID string) (*internal.Config, string) {
+	cfg := &internal.Config{}
+	if empID == internal.CurrentUserID {
+		if local, err := internal.LoadConfig(); err == nil {
+			cfg = local
+		}
+	}
+
+	if found, err := tabExists(client, targetsSheet); err != nil || !found {
+		return cfg, "local settings"
+	}
+
+	resp, err := client.Get(targetsSheet + "!A2:D")
+	if err != nil {
+		log.Printf("⚠️ Could not load targets: %v", err)
+		return cfg, "local settings"
+	}
+	for _, row := range resp {
+		if cellString(row, 0) != empID {
+			continue
+		}
+		daily, weekly := parseFloat(cellString(row, 1)), parseFloat(cellString(row, 2))
+		if daily > 0 || weekly > 0 {
+			cfg.HoursPerDay, cfg.HoursPerWeek = daily, weekly
+		}
+		if days := cellString(row, 3); days != "" {
+			cfg.WorkDays = days
+		}
+		return cfg, "set by admin"
+	}
+	return cfg, "local settings"
+}
+
+// period totals the target and logged hours over a range of days.
+type period struct {
+	Target   float64
+	Logged   float64
+	WorkDays int
+	Holidays int
+	Leave    float64
+}
+
+func (p period) Diff() float64 {
+	return p.Logged - p.Target
+}
+
+// measurePeriod sums target and logged hours for every day in [from, to].
+// Leave lowers the target of its day. Holidays and hours on non-working
+// days follow the configured holiday and weekend rules.
+func measurePeriod(cfg *internal.Config, entries []sheetEntry, holidays map[string]string, from, to time.Time) period {
+	logged := map[string]float64{}
+	for _, e := range entries {
+		if e.Bucket != leaveBucket {
+			logged[e.Date.Format("02/01/06")] += e.Hours
+		}
+	}
+
+	var p period
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format("02/01/06")
+		workDay := cfg.IsWorkDay(d)
+		holiday := false
+		if _, ok := holidays[date]; ok && workDay && cfg.HolidayPolicy() == internal.HolidayOff {
+			p.Holidays++
+			workDay, holiday = false, true
+		}
+
+		if !workDay {
+			// Work on a holiday is always overtime; on other days off it
+			// depends on the weekend rule.
+			if holiday || cfg.WeekendPolicy() == internal.WeekendCount {
+				p.Logged += logged[date]
+			}
+			continue
+		}
+
+		p.WorkDays++
+		p.Logged += logged[date]
+		due := cfg.DailyHours()
+		leave := leaveHoursOn(entries, date)
+		if leave > due {
+			leave = due
+		}
+		p.Leave += leave
+		p.Target += due - leave
+	}
+	return p
+}
+
+// weekBalance is one week of the overtime balance.
+type weekBalance struct {
+	Monday  time.Time
+	period  period
+	Balance float64
+}
+
+// overtimeBalance measures every week from the one containing since up to
+// the one containing today, carrying the running balance.
+func overtimeBalance(cfg *internal.Config, entries []sheetEntry, holidays map[string]string, since, today time.Time) []weekBalance {
+	var weeks []weekBalance
+	balance := 0.0
+	for monday := weekDays(since)[0]; !monday.After(today); monday = monday.AddDate(0, 0, 7) {
+		from, to := monday, monday.AddDate(0, 0, 6)
+		if from.Before(since) {
+			from = since
+		}
+		if to.After(today) {
+			to = today
+		}
+		p := measurePeriod(cfg, entries, holidays, from, to)
+		balance += p.Diff()
+		weeks = append(weeks, weekBalance{Monday: monday, period: p, Balance: balance})
+	}
+	return weeks
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// 12 October 2026 is a Monday.
+func day(d int) time.Time {
+	return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC)
+}
+
+func workWeek(hours float64) []sheetEntry {
+	var entries []sheetEntry
+	for d := 12; d <= 16; d++ {
+		entries = append(entries, sheetEntry{Date: day(d), Bucket: "general", Hours: hours})
+	}
+	return entries
+}
+
+func TestMeasurePeriod(t *testing.T) {
+	tuesday := map[string]string{day(13).Format("02/01/06"): "Holiday"}
+	tests := []struct {
+		name     string
+		cfg      internal.Config
+		entries  []sheetEntry
+		holidays map[string]string
+		want     period
+	}{
+		{
+			name:    "full week",
+			entries: workWeek(8),
+			want:    period{Target: 40, Logged: 40, WorkDays: 5},
+		},
+		{
+			name:    "day of leave",
+			entries: append(workWeek(6), sheetEntry{Date: day(14), Bucket: leaveBucket, Hours: 8}),
+			want:    period{Target: 32, Logged: 30, WorkDays: 5, Leave: 8},
+		},
+		{
+			name:    "leave capped at the day's hours",
+			entries: []sheetEntry{{Date: day(14), Bucket: leaveBucket, Hours: 10}},
+			want:    period{Target: 32, WorkDays: 5, Leave: 8},
+		},
+		{
+			name:     "holiday off",
+			entries:  workWeek(8),
+			holidays: tuesday,
+			want:     period{Target: 32, Logged: 40, WorkDays: 4, Holidays: 1},
+		},
+		{
+			name:     "holiday as workday",
+			cfg:      internal.Config{HolidayRule: internal.HolidayWorkday},
+			entries:  workWeek(8),
+			holidays: tuesday,
+			want:     period{Target: 40, Logged: 40, WorkDays: 5},
+		},
+		{
+			name:    "weekend counted",
+			entries: append(workWeek(8), sheetEntry{Date: day(17), Bucket: "general", Hours: 3}),
+			want:    period{Target: 40, Logged: 43, WorkDays: 5},
+		},
+		{
+			name:    "weekend ignored",
+			cfg:     internal.Config{WeekendRule: internal.WeekendIgnore},
+			entries: append(workWeek(8), sheetEntry{Date: day(17), Bucket: "general", Hours: 3}),
+			want:    period{Target: 40, Logged: 40, WorkDays: 5},
+		},
+		{
+			name:    "weekly hours over custom days",
+			cfg:     internal.Config{HoursPerWeek: 30, WorkDays: "mon-wed"},
+			entries: workWeek(8),
+			want:    period{Target: 30, Logged: 40, WorkDays: 3},
+		},
+	}
+	for _, tt := range tests {
+		cfg := tt.cfg
+		if got := measurePeriod(&cfg, tt.entries, tt.holidays, day(12), day(18)); got != tt.want {
+			t.Errorf("%s: measurePeriod = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOvertimeBalance(t *testing.T) {
+	entries := append(workWeek(9), sheetEntry{Date: day(19), Bucket: "general", Hours: 6})
+	cfg := internal.Config{}
+
+	// Since Wednesday, up to the next Monday.
+	weeks := overtimeBalance(&cfg, entries, nil, day(14), day(19))
+	if len(weeks) != 2 {
+		t.Fatalf("got %d weeks, want 2", len(weeks))
+	}
+	tests := []struct {
+		monday  time.Time
+		target  float64
+		logged  float64
+		balance float64
+	}{
+		{day(12), 24, 27, 3},
+		{day(19), 8, 6, 1},
+	}
+	for i, tt := range tests {
+		w := weeks[i]
+		if !w.Monday.Equal(tt.monday) || w.period.Target != tt.target || w.period.Logged != tt.logged || w.Balance != tt.balance {
+			t.Errorf("week %d = %v %+v balance %v, want %v target %v logged %v balance %v",
+				i, w.Monday.Format("2006-01-02"), w.period, w.Balance, tt.monday.Format("2006-01-02"), tt.target, tt.logged, tt.balance)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+	"github.com/srikanth-karthi/timesheet/internal/store"
+)
+
+// teamReport prints per-person by bucket and by day (or by week with --all)
+// totals for the users the current user may see: everyone for admins,
+// direct reports for managers. All sheets are read in one batched request.
+// Leave is not worked time: it is left out of the totals and shown in a
+// column of its own.
+func teamReport(client store.Client, monday, sunday time.Time, filterTags []string) {
+	me := internal.CurrentUserID
+	requireRole(client, me, roleManager, roleAdmin)
+
+	users, err := loadAdminUsers(client)
+	if err != nil {
+		log.Fatalf("  Failed to read users: %v", err)
+	}
+	role, _ := userRole(client, me)
+
+	var team []string
+	for _, u := range users {
+		if u.Status != userActive {
+			continue
+		}
+		if role == roleAdmin || u.Manager == me {
+			team = append(team, u.EmpID)
+		}
+	}
+	if len(team) == 0 {
+		fmt.Println("ℹ️ Nobody reports to you. Set the manager column in the admin tab.")
+		return
+	}
+	sort.Strings(team)
+
+	all, err := fetchUsersEntries(client, team)
+	if err != nil {
+		log.Fatalf("  Failed to fetch timesheets: %v", err)
+	}
+
+	from, to := dateOf(monday), dateOf(sunday)
+	byBucket := map[string]map[string]float64{}
+	byPeriod := map[string]map[string]float64{}
+	personTotals := map[string]float64{}
+	personLeave := map[string]float64{}
+	bucketSet := map[string]bool{}
+	periodSet := map[string]bool{}
+	for _, person := range team {
+		byBucket[person] = map[string]float64{}
+		byPeriod[person] = map[string]float64{}
+		for _, e := range all[person] {
+			if !showAll && (e.Date.Before(from) || e.Date.After(to)) {
+				continue
+			}
+			if len(filterTags) > 0 && !e.hasAnyTag(filterTags) {
+				continue
+			}
+			if e.Bucket == leaveBucket {
+				personLeave[person] += e.Hours
+				continue
+			}
+			key := e.Date.Format("Mon 02")
+			if showAll {
+				key = weekDays(e.Date)[0].Format("2006-01-02")
+			}
+			byBucket[person][e.Bucket] += e.Hours
+			byPeriod[person][key] += e.Hours
+			personTotals[person] += e.Hours
+			bucketSet[e.Bucket] = true
+			periodSet[key] = true
+		}
+	}
+
+	if showAll {
+		fmt.Println("\n👥 Team report, all entries")
+	} else {
+		_, week := monday.ISOWeek()
+		fmt.Printf("\n👥 Team report, week %d (%s – %s)\n", week, monday.Format("Jan 02"), sunday.Format("Jan 02"))
+	}
+
+	buckets := sortedKeys(bucketSet)
+	fmt.Println("\n📁 Hours by person and bucket:")
+	printMatrix(team, buckets, byBucket, personTotals, personLeave)
+
+	var periods []string
+	if showAll {
+		periods = sortedKeys(periodSet)
+		fmt.Println("\n📆 Hours by person and week:")
+	} else {
+		for _, d := range weekDays(from) {
+			periods = append(periods, d.Format("Mon 02"))
+		}
+		fmt.Println("\n📆 Hours by person and day:")
+	}
+	printMatrix(team, periods, byPeriod, personTotals, nil)
+
+	var missing []string
+	for _, person := range team {
+		if _, ok := all[person]; !ok {
+			missing = append(missing, person+" (no sheet)")
+		} else if personTotals[person] == 0 && personLeave[person] == 0 {
+			missing = append(missing, person)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Printf("\n⚠️  Nothing logged by: %s\n", strings.Join(missing, ", "))
+	}
+	fmt.Println()
+}
+
+// printMatrix prints one row per person and one column per key, with row
+// and column totals. A non-empty leave adds a leave column after the total.
+func printMatrix(people, columns []string, cells map[string]map[string]float64, totals, leave map[string]float64) {
+	width := 8
+	for _, c := range columns {
+		if len(c)+1 > width {
+			width = len(c) + 1
+		}
+	}
+
+	fmt.Printf("%-10s", "")
+	for _, c := range columns {
+		fmt.Printf("%*s", width, c)
+	}
+	fmt.Printf("%*s", width, "total")
+	if len(leave) > 0 {
+		fmt.Printf("%*s", width, leaveBucket)
+	}
+	fmt.Println()
+
+	colTotals := map[string]float64{}
+	grand := 0.0
+	for _, p := range people {
+		fmt.Printf("%-10s", p)
+		for _, c := range columns {
+			fmt.Printf("%*.1f", width, cells[p][c])
+			colTotals[c] += cells[p][c]
+		}
+		fmt.Printf("%*.1f", width, totals[p])
+		if len(leave) > 0 {
+			fmt.Printf("%*.1f", width, leave[p])
+		}
+		fmt.Println()
+		grand += totals[p]
+	}
+
+	fmt.Printf("%-10s", "total")
+	for _, c := range columns {
+		fmt.Printf("%*.1f", width, colTotals[c])
+	}
+	fmt.Printf("%*.1f", width, grand)
+	if len(leave) > 0 {
+		sum := 0.0
+		for _, p := range people {
+			sum += leave[p]
+		}
+		fmt.Printf("%*.1f", width, sum)
+	}
+	fmt.Println()
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
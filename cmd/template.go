@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+var (
+	templateBucket string
+	templateHours  float64
+	templateTask   string
+	templateOn     string
+
+	templateWeek bool
+	templateDate string
+	templateDry  bool
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "🔁 Manage recurring entries like standups and 1:1s",
+	Long: `Manage recurring entry templates.
+
+Templates are stored in ~/.timesheet/config.json and logged with
+'template apply', which skips days that already have a matching entry
+(same bucket and task), leave or a public holiday.`,
+}
+
+var templateAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a recurring entry template",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if templateBucket == "" || templateTask == "" || templateHours <= 0 {
+			fmt.Println("  Please provide --bucket, --task and --hours.")
+			cmd.Usage()
+			os.Exit(1)
+		}
+		if _, err := internal.ParseWeekdays(templateOn); err != nil {
+			log.Fatalf("  Invalid --on: %v", err)
+		}
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+		client := getSheetsClient()
+		valid, err := bucketExists(client, internal.CurrentUserID, templateBucket)
+		if err != nil {
+			log.Fatalf("  Could not fetch buckets: %v", err)
+		}
+		if !valid {
+			log.Fatalf("  Bucket '%s' is not valid. Use 'timesheet bucket' to view available ones.", templateBucket)
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		cfg.SetTemplate(internal.EntryTemplate{
+			Name:   args[0],
+			Bucket: templateBucket,
+			Task:   templateTask,
+			Hours:  templateHours,
+			On:     templateOn,
+		})
+		if err := internal.SaveConfig(cfg); err != nil {
+			log.Fatalf("  Failed to save config: %v", err)
+		}
+		fmt.Printf("🔁 Template '%s': %.2f hrs '%s' in '%s' on %s\n", args[0], templateHours, templateTask, templateBucket, templateOn)
+	},
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring entry templates",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		if len(cfg.Templates) == 0 {
+			fmt.Println("ℹ️ No templates. Add one with 'timesheet template add'.")
+			return
+		}
+		for _, t := range cfg.Templates {
+			fmt.Printf("  %-12s %-10s %5.2f hrs  %-10s %s\n", t.Name, t.Bucket, t.Hours, t.On, t.Task)
+		}
+	},
+}
+
+var templateRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a recurring entry template",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		if !cfg.RemoveTemplate(args[0]) {
+			fmt.Printf("  No template named '%s'.\n", args[0])
+			os.Exit(1)
+		}
+		if err := internal.SaveConfig(cfg); err != nil {
+			log.Fatalf("  Failed to save config: %v", err)
+		}
+		fmt.Printf("🗑️  Removed template '%s'\n", args[0])
+	},
+}
+
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply [name...]",
+	Short: "Log all due template entries for a day or week",
+	Long: `Log the entries of all templates (or only the named ones) that are due
+in the period: today by default, the given --date, or with --week the
+Monday to Sunday week containing it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !internal.IsLoggedIn() {
+			fmt.Println("  Please run 'timesheet setup' first.")
+			os.Exit(1)
+		}
+
+		cfg, err := internal.LoadConfig()
+		if err != nil {
+			log.Fatalf("  Failed to read config: %v", err)
+		}
+		templates := selectTemplates(cfg.Templates, args)
+		if len(templates) == 0 {
+			fmt.Println("ℹ️ No templates to apply.")
+			return
+		}
+
+		loc := userLocation()
+		day := time.Now().In(loc)
+		if templateDate != "" {
+			day, err = time.ParseInLocation("02/01/06", templateDate, loc)
+			if err != nil {
+				log.Fatalf("  Invalid date format. Use dd/mm/yy")
+			}
+		}
+		days := []time.Time{day}
+		if templateWeek {
+			days = weekDays(day)
+		}
+
+		client := getSheetsClient()
+		userSheet := internal.CurrentUserID
+		// A bucket may have been removed since the template was added.
+		checked := map[string]bool{}
+		for _, t := range templates {
+			if checked[t.Bucket] {
+				continue
+			}
+			valid, err := bucketExists(client, userSheet, t.Bucket)
+			if err != nil {
+				log.Fatalf("  Could not fetch buckets: %v", err)
+			}
+			if !valid {
+				log.Fatalf("  Bucket '%s' of template '%s' is not valid. Use 'timesheet bucket' to view available ones.", t.Bucket, t.Name)
+			}
+			checked[t.Bucket] = true
+		}
+		entries, err := fetchEntries(client, userSheet)
+		if err != nil {
+			log.Fatalf("  Failed to read timesheet rows: %v", err)
+		}
+		holidays, err := loadHolidays(client, userSheet)
+		if err != nil {
+			log.Printf("⚠️ Could not load holidays: %v", err)
+		}
+
+		var rows [][]interface{}
+		var logged []time.Time
+		for _, d := range days {
+			date := d.Format("02/01/06")
+			if onLeave(entries, date) {
+				fmt.Printf("🏖️  %s %s is a leave day, skipped.\n", d.Format("Mon"), date)
+				continue
+			}
+			if name, ok := holidays[date]; ok {
+				fmt.Printf("🎉 %s %s is a holiday (%s), skipped.\n", d.Format("Mon"), date, name)
+				continue
+			}
+			for _, t := range templates {
+				if !t.DueOn(d) {
+					continue
+				}
+				if hasMatchingEntry(entries, date, t.Bucket, t.Task) {
+					fmt.Printf("⏭️  %s %s '%s' already logged.\n", d.Format("Mon"), date, t.Task)
+					continue
+				}
+
+				stamp := time.Date(d.Year(), d.Month(), d.Day(), 9, 0, 0, 0, loc)
+				tags := internal.ParseTags(t.Task)
+				rows = append(rows, []interface{}{
+					date, d.Format("Monday"), t.Bucket, t.Task, fmt.Sprintf("%.2f", t.Hours),
+					stamp.Format(time.RFC3339), "", internal.JoinTags(tags), detectTicket(t.Task),
+				})
+				logged = append(logged, d)
+				fmt.Printf("➕ %s %s %5.2f hrs '%s' in '%s'\n", d.Format("Mon"), date, t.Hours, t.Task, t.Bucket)
+			}
+		}
+
+		if len(rows) == 0 {
+			fmt.Println("ℹ️ Nothing to log.")
+			return
+		}
+		if templateDry {
+			fmt.Printf("🧪 Dry run: %d entries not logged.\n", len(rows))
+			return
+		}
+		ensureWeeksOpen(client, userSheet, logged...)
+		if err := client.Append(userSheet+"!A3:J", rows, "USER_ENTERED"); err != nil {
+			log.Fatalf("  Failed to log template entries: %v", err)
+		}
+		fmt.Printf("   Logged %d template entries.\n", len(rows))
+	},
+}
+
+// selectTemplates returns the named templates, or all when names is empty.
+func selectTemplates(all []internal.EntryTemplate, names []string) []internal.EntryTemplate {
+	if len(names) == 0 {
+		return all
+	}
+	var selected []internal.EntryTemplate
+	for _, name := range names {
+		found := false
+		for _, t := range all {
+			if t.Name == name {
+				selected = append(selected, t)
+				found = true
+			}
+		}
+		if !found {
+			log.Fatalf("  No template named '%s'.", name)
+		}
+	}
+	return selected
+}
+
+// weekDays returns Monday to Sunday of the week containing day.
+func weekDays(day time.Time) []time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	monday := time.Date(day.Year(), day.Month(), day.Day()-offset, 0, 0, 0, 0, day.Location())
+	days := make([]time.Time, 7)
+	for i := range days {
+		days[i] = monday.AddDate(0, 0, i)
+	}
+	return days
+}
+
+func hasMatchingEntry(entries []sheetEntry, date, bucket, task string) bool {
+	for _, e := range entries {
+		if e.Date.Format("02/01/06") == date && e.Bucket == bucket && strings.EqualFold(e.Task, task) {
+			return true
+		}
+	}
+	return false
+}
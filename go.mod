@@ -4,6 +4,7 @@ go 1.24.1
 
 require (
 	github.com/spf13/cobra v1.9.1
+	golang.org/x/sys v0.31.0
 	google.golang.org/api v0.228.0
 )
 
@@ -28,7 +29,6 @@ require (
 	golang.org/x/crypto v0.36.0 // indirect
 	golang.org/x/net v0.37.0 // indirect
 	golang.org/x/oauth2 v0.28.0 // indirect
-	golang.org/x/sys v0.31.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250313205543-e70fdf4c4cb4 // indirect
 	google.golang.org/grpc v1.71.0 // indirect
@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Span is a half-open interval of working time.
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (s Span) Hours() float64 {
+	return s.End.Sub(s.Start).Hours()
+}
+
+// SplitByDay cuts [start, end) at every midnight in loc.
+func SplitByDay(start, end time.Time, loc *time.Location) []Span {
+	var spans []Span
+	cur := start.In(loc)
+	end = end.In(loc)
+	for cur.Before(end) {
+		next := time.Date(cur.Year(), cur.Month(), cur.Day()+1, 0, 0, 0, 0, loc)
+		if next.After(end) {
+			next = end
+		}
+		spans = append(spans, Span{Start: cur, End: next})
+		cur = next
+	}
+	return spans
+}
+
+// ShareOverlaps returns each span's hours with time covered by several spans
+// divided evenly among them, so parallel timers are not double-counted.
+//
+// It sweeps the sorted span boundaries once, accumulating the hours one
+// span would get if it were open from the first boundary on; a span's share
+// is that running total at its end minus the total at its start.
+func ShareOverlaps(spans []Span) []float64 {
+	type boundary struct {
+		at    time.Time
+		span  int
+		start bool
+	}
+	bounds := make([]boundary, 0, 2*len(spans))
+	for i, s := range spans {
+		if !s.End.After(s.Start) {
+			continue
+		}
+		bounds = append(bounds, boundary{s.Start, i, true}, boundary{s.End, i, false})
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].at.Before(bounds[j].at) })
+
+	shares := make([]float64, len(spans))
+	open := 0
+	shared := 0.0
+	for i, b := range bounds {
+		if i > 0 && open > 0 {
+			shared += b.at.Sub(bounds[i-1].at).Hours() / float64(open)
+		}
+		if b.start {
+			shares[b.span] -= shared
+			open++
+		} else {
+			shares[b.span] += shared
+			open--
+		}
+	}
+	return shares
+}
+
+// TouchInvocation records now as the last CLI invocation and returns the
+// previously recorded one (zero if none).
+func TouchInvocation(now time.Time) (time.Time, error) {
+	var previous time.Time
+	err := UpdateMeta(func(m *Meta) error {
+		previous, _ = time.Parse(time.RFC3339, m.LastInvocation)
+		m.LastInvocation = now.Format(time.RFC3339)
+		return nil
+	})
+	return previous, err
+}
+
+// LastActivity returns the latest activity signal inside (after, before):
+// timestamps from zsh, bash and fish history files plus the extra times
+// passed in (such as the previous CLI invocation). Zero if none is found.
+func LastActivity(after, before time.Time, extra ...time.Time) time.Time {
+	candidates := append([]time.Time(nil), extra...)
+	home := os.Getenv("HOME")
+	candidates = append(candidates, historyTimes(filepath.Join(home, ".zsh_history"), zshTimestamp)...)
+	candidates = append(candidates, historyTimes(filepath.Join(home, ".bash_history"), bashTimestamp)...)
+	candidates = append(candidates, historyTimes(filepath.Join(home, ".local", "share", "fish", "fish_history"), fishTimestamp)...)
+
+	var latest time.Time
+	for _, t := range candidates {
+		if t.After(after) && t.Before(before) && t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+func historyTimes(path string, parse func(string) (int64, bool)) []time.Time {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var times []time.Time
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if secs, ok := parse(scanner.Text()); ok {
+			times = append(times, time.Unix(secs, 0))
+		}
+	}
+	return times
+}
+
+// zshTimestamp parses EXTENDED_HISTORY lines ": 1700000000:0;command".
+func zshTimestamp(line string) (int64, bool) {
+	if !strings.HasPrefix(line, ": ") {
+		return 0, false
+	}
+	ts, _, found := strings.Cut(line[2:], ":")
+	if !found {
+		return 0, false
+	}
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	return secs, err == nil
+}
+
+// bashTimestamp parses the "#1700000000" lines bash writes with HISTTIMEFORMAT.
+func bashTimestamp(line string) (int64, bool) {
+	if !strings.HasPrefix(line, "#") {
+		return 0, false
+	}
+	secs, err := strconv.ParseInt(line[1:], 10, 64)
+	return secs, err == nil
+}
+
+// fishTimestamp parses "  when: 1700000000" lines.
+func fishTimestamp(line string) (int64, bool) {
+	ts, found := strings.CutPrefix(strings.TrimSpace(line), "when: ")
+	if !found {
+		return 0, false
+	}
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	return secs, err == nil
+}
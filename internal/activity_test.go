@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSplitByDay(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	at := func(loc *time.Location, d, h, m int) time.Time {
+		return time.Date(2026, time.October, d, h, m, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		loc        *time.Location
+		want       []float64
+	}{
+		{"same day", at(time.UTC, 12, 9, 0), at(time.UTC, 12, 17, 30), time.UTC, []float64{8.5}},
+		{"over midnight", at(time.UTC, 12, 22, 0), at(time.UTC, 13, 1, 30), time.UTC, []float64{2, 1.5}},
+		{"ends at midnight", at(time.UTC, 12, 20, 0), at(time.UTC, 13, 0, 0), time.UTC, []float64{4}},
+		{"several days", at(time.UTC, 12, 18, 0), at(time.UTC, 14, 6, 0), time.UTC, []float64{6, 24, 6}},
+		{"empty", at(time.UTC, 12, 9, 0), at(time.UTC, 12, 9, 0), time.UTC, nil},
+		{"reversed", at(time.UTC, 12, 10, 0), at(time.UTC, 12, 9, 0), time.UTC, nil},
+		// 23:00 UTC is already the next day in Berlin.
+		{"midnight of loc", at(time.UTC, 12, 21, 0), at(time.UTC, 12, 23, 0), berlin, []float64{1, 1}},
+		// Clocks go back on 25 October 2026, making that day 25 hours long.
+		{"daylight saving", at(berlin, 24, 23, 0), at(berlin, 26, 1, 0), berlin, []float64{1, 25, 1}},
+	}
+	for _, tt := range tests {
+		spans := SplitByDay(tt.start, tt.end, tt.loc)
+		if len(spans) != len(tt.want) {
+			t.Errorf("%s: got %d spans, want %d", tt.name, len(spans), len(tt.want))
+			continue
+		}
+		for i, s := range spans {
+			if s.Hours() != tt.want[i] {
+				t.Errorf("%s: span %d is %v hours, want %v", tt.name, i, s.Hours(), tt.want[i])
+			}
+			if i > 0 && !s.Start.Equal(spans[i-1].End) {
+				t.Errorf("%s: span %d does not start where span %d ends", tt.name, i, i-1)
+			}
+		}
+	}
+}
+
+func TestShareOverlaps(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2026, time.October, 12, h, m, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		spans []Span
+		want  []float64
+	}{
+		{"none", nil, []float64{}},
+		{"apart", []Span{{at(9, 0), at(10, 0)}, {at(11, 0), at(13, 0)}}, []float64{1, 2}},
+		{"touching", []Span{{at(9, 0), at(10, 0)}, {at(10, 0), at(11, 0)}}, []float64{1, 1}},
+		{"identical", []Span{{at(9, 0), at(11, 0)}, {at(9, 0), at(11, 0)}}, []float64{1, 1}},
+		{"partial", []Span{{at(9, 0), at(11, 0)}, {at(10, 0), at(12, 0)}}, []float64{1.5, 1.5}},
+		{"nested", []Span{{at(9, 0), at(13, 0)}, {at(10, 0), at(11, 0)}}, []float64{3.5, 0.5}},
+		{"three way", []Span{{at(9, 0), at(12, 0)}, {at(9, 0), at(12, 0)}, {at(10, 0), at(11, 0)}}, []float64{4.0 / 3, 4.0 / 3, 1.0 / 3}},
+		{"zero length", []Span{{at(9, 0), at(11, 0)}, {at(10, 0), at(10, 0)}}, []float64{2, 0}},
+	}
+	for _, tt := range tests {
+		got := ShareOverlaps(tt.spans)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d shares, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+				t.Errorf("%s: ShareOverlaps = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Commit struct {
+	Repo    string
+	Time    time.Time
+	Subject string
+}
+
+// WorkSession is a run of commits in one repository with no gap longer
+// than the clustering threshold.
+type WorkSession struct {
+	Repo     string
+	Start    time.Time
+	End      time.Time
+	Subjects []string
+	Hours    float64
+}
+
+// GitAuthorEmail returns the user.email git would use inside repo.
+func GitAuthorEmail(repo string) string {
+	out, err := exec.Command("git", "-C", repo, "config", "user.email").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ReadCommits lists non-merge commits by author in repo between from and to,
+// using the local git binary only.
+func ReadCommits(repo, author string, from, to time.Time) ([]Commit, error) {
+	out, err := exec.Command("git", "-C", repo, "log", "--all", "--no-merges",
+		"--author="+author,
+		"--since="+from.Format(time.RFC3339),
+		"--until="+to.Format(time.RFC3339),
+		"--format=%at%x09%s",
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log in %s: %v", repo, err)
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		ts, subject, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		secs, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, Commit{Repo: repo, Time: time.Unix(secs, 0), Subject: subject})
+	}
+	return commits, nil
+}
+
+// ClusterSessions groups commits per repository and day into sessions split
+// wherever two consecutive commits are more than gap apart. Each session is
+// credited lead time before its first commit, and hours are rounded up to
+// the nearest quarter hour.
+func ClusterSessions(commits []Commit, gap, lead time.Duration) []WorkSession {
+	sorted := append([]Commit(nil), commits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	var sessions []WorkSession
+	open := map[string]int{} // repo → index of its latest session
+	for _, c := range sorted {
+		i, ok := open[c.Repo]
+		if ok {
+			s := &sessions[i]
+			sameDay := s.End.Format("2006-01-02") == c.Time.Format("2006-01-02")
+			if sameDay && c.Time.Sub(s.End) <= gap {
+				s.End = c.Time
+				s.Subjects = append(s.Subjects, c.Subject)
+				continue
+			}
+		}
+		sessions = append(sessions, WorkSession{Repo: c.Repo, Start: c.Time, End: c.Time, Subjects: []string{c.Subject}})
+		open[c.Repo] = len(sessions) - 1
+	}
+
+	for i := range sessions {
+		worked := sessions[i].End.Sub(sessions[i].Start) + lead
+		sessions[i].Hours = math.Ceil(worked.Hours()*4) / 4
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Start.Before(sessions[j].Start) })
+	return sessions
+}
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RepoMapping ties a project directory to the bucket its work is logged in.
+type RepoMapping struct {
+	Path   string `json:"path"`
+	Bucket string `json:"bucket"`
+}
+
+type Config struct {
+	Repos           []RepoMapping   `json:"repos,omitempty"`
+	MaxSessionHours float64         `json:"max_session_hours,omitempty"`
+	Timezone        string          `json:"timezone,omitempty"`
+	ParallelTime    string          `json:"parallel_time,omitempty"`
+	Templates       []EntryTemplate `json:"templates,omitempty"`
+	HoursPerDay     float64         `json:"hours_per_day,omitempty"`
+	HoursPerWeek    float64         `json:"hours_per_week,omitempty"`
+	WorkDays        string          `json:"work_days,omitempty"`
+	WeekendRule     string          `json:"weekend_rule,omitempty"`
+	HolidayRule     string          `json:"holiday_rule,omitempty"`
+	OvertimeSince   string          `json:"overtime_since,omitempty"`
+	BudgetWarn      []float64       `json:"budget_warn,omitempty"`
+	TicketPrefixes  []string        `json:"ticket_prefixes,omitempty"`
+}
+
+var defaultBudgetWarn = []float64{80, 100}
+
+// BudgetThresholds are the percentages of a bucket budget at which 'log',
+// 'start' and 'stop' warn.
+func (c *Config) BudgetThresholds() []float64 {
+	if len(c.BudgetWarn) == 0 {
+		return defaultBudgetWarn
+	}
+	return c.BudgetWarn
+}
+
+// Weekend rules: hours logged on non-working days count towards overtime or
+// are ignored. Holiday rules: a holiday is a day off or a normal working day.
+const (
+	WeekendCount   = "count"
+	WeekendIgnore  = "ignore"
+	HolidayOff     = "off"
+	HolidayWorkday = "workday"
+)
+
+func (c *Config) WeekendPolicy() string {
+	if c.WeekendRule == WeekendIgnore {
+		return WeekendIgnore
+	}
+	return WeekendCount
+}
+
+func (c *Config) HolidayPolicy() string {
+	if c.HolidayRule == HolidayWorkday {
+		return HolidayWorkday
+	}
+	return HolidayOff
+}
+
+// Parallel time policies: with ParallelOverlap every timer keeps its full
+// duration in reports, with ParallelSplit overlapping time is shared.
+const (
+	ParallelOverlap = "overlap"
+	ParallelSplit   = "split"
+)
+
+func (c *Config) ParallelPolicy() string {
+	if c.ParallelTime == ParallelSplit {
+		return ParallelSplit
+	}
+	return ParallelOverlap
+}
+
+// Location is the configured timezone used to decide which day time belongs
+// to, defaulting to the system's local zone.
+func (c *Config) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+const defaultMaxSessionHours = 10
+
+// MaxSession is the longest session 'stop' accepts without asking.
+func (c *Config) MaxSession() time.Duration {
+	hours := c.MaxSessionHours
+	if hours <= 0 {
+		hours = defaultMaxSessionHours
+	}
+	return time.Duration(hours * float64(time.Hour))
+}
+
+const defaultHoursPerDay = 8
+
+// DailyHours is the number of hours expected on a working day: the
+// configured daily hours, else the weekly hours spread over the working
+// days, else 8.
+func (c *Config) DailyHours() float64 {
+	if c.HoursPerDay > 0 {
+		return c.HoursPerDay
+	}
+	if c.HoursPerWeek > 0 {
+		days, err := ParseWeekdays(c.WorkDays)
+		if err == nil && len(days) > 0 {
+			return c.HoursPerWeek / float64(len(days))
+		}
+	}
+	return defaultHoursPerDay
+}
+
+// IsWorkDay reports whether day is one of the configured working weekdays
+// (Monday to Friday by default).
+func (c *Config) IsWorkDay(day time.Time) bool {
+	days, err := ParseWeekdays(c.WorkDays)
+	if err != nil {
+		days, _ = ParseWeekdays("")
+	}
+	return days[day.Weekday()]
+}
+
+var configPath = filepath.Join(configDir, "config.json")
+
+func LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return &cfg, fmt.Errorf("%s is not valid JSON: %w", configPath, err)
+	}
+	return &cfg, nil
+}
+
+func SaveConfig(cfg *Config) error {
+	os.MkdirAll(filepath.Dir(configPath), 0755)
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(configPath, data, 0644)
+}
+
+// MapRepo adds or replaces the mapping for path.
+func (c *Config) MapRepo(path, bucket string) {
+	for i, r := range c.Repos {
+		if r.Path == path {
+			c.Repos[i].Bucket = bucket
+			return
+		}
+	}
+	c.Repos = append(c.Repos, RepoMapping{Path: path, Bucket: bucket})
+}
+
+// UnmapRepo removes the mapping for path and reports whether one existed.
+func (c *Config) UnmapRepo(path string) bool {
+	for i, r := range c.Repos {
+		if r.Path == path {
+			c.Repos = append(c.Repos[:i], c.Repos[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// BucketForDir returns the bucket of the most specific mapping containing dir.
+func (c *Config) BucketForDir(dir string) string {
+	best, bucket := "", ""
+	for _, r := range c.Repos {
+		rel, err := filepath.Rel(r.Path, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(r.Path) > len(best) {
+			best, bucket = r.Path, r.Bucket
+		}
+	}
+	return bucket
+}
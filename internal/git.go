@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FindGitDir walks up from dir to the enclosing repository and returns its
+// work tree root and .git directory. Worktrees and submodules, where .git is
+// a file pointing elsewhere, are followed.
+func FindGitDir(dir string) (root, gitDir string, ok bool) {
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			if info.IsDir() {
+				return dir, candidate, true
+			}
+			data, err := os.ReadFile(candidate)
+			if err == nil && strings.HasPrefix(string(data), "gitdir:") {
+				target := strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir:"))
+				if !filepath.IsAbs(target) {
+					target = filepath.Join(dir, target)
+				}
+				return dir, target, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+// CurrentBranch reads HEAD and returns the checked out branch name, or ""
+// when HEAD is detached.
+func CurrentBranch(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	head := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(head, "ref: refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(head, "ref: refs/heads/")
+}
+
+// LastCommitSubject returns the subject of the last commit recorded in the
+// HEAD reflog, or "" if there is none.
+func LastCommitSubject(gitDir string) string {
+	f, err := os.Open(filepath.Join(gitDir, "logs", "HEAD"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	subject := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		_, msg, found := strings.Cut(scanner.Text(), "\t")
+		if !found {
+			continue
+		}
+		for _, prefix := range []string{"commit (initial): ", "commit (amend): ", "commit: "} {
+			if strings.HasPrefix(msg, prefix) {
+				subject = strings.TrimPrefix(msg, prefix)
+				break
+			}
+		}
+	}
+	return subject
+}
+
+var (
+	branchTypePrefix = regexp.MustCompile(`^(feature|feat|fix|bugfix|hotfix|chore|docs|refactor|release)/`)
+	branchSeparators = regexp.MustCompile(`[-_/]+`)
+	branchTicket     = regexp.MustCompile(`(?i)^([a-z][a-z0-9]+-\d+)[-_/]?`)
+)
+
+// TaskFromBranch turns a branch name like "feature/PROJ-12-add-login" into
+// a task description like "PROJ-12 add login". Mainline branches carry no
+// task information and yield "".
+func TaskFromBranch(branch string) string {
+	switch branch {
+	case "", "main", "master", "develop", "dev", "trunk":
+		return ""
+	}
+
+	name := branchTypePrefix.ReplaceAllString(branch, "")
+	ticket := ""
+	if m := branchTicket.FindStringSubmatch(name); m != nil {
+		ticket = strings.ToUpper(m[1])
+		name = name[len(m[0]):]
+	}
+
+	words := strings.TrimSpace(branchSeparators.ReplaceAllString(name, " "))
+	return strings.TrimSpace(ticket + " " + words)
+}
+
+// GitTaskHint suggests a task description for the repository at gitDir,
+// preferring the branch name and falling back to the last commit subject.
+func GitTaskHint(gitDir string) string {
+	if task := TaskFromBranch(CurrentBranch(gitDir)); task != "" {
+		return task
+	}
+	return LastCommitSubject(gitDir)
+}
@@ -0,0 +1,26 @@
+package internal
+
+import "testing"
+
+func TestTaskFromBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"feature/PROJ-12-add-login", "PROJ-12 add login"},
+		{"fix/proj-7_null_pointer", "PROJ-7 null pointer"},
+		{"hotfix/ABC-3", "ABC-3"},
+		{"add-dark-mode", "add dark mode"},
+		{"chore/bump-deps", "bump deps"},
+		{"user/jo/experiment", "user jo experiment"},
+		{"main", ""},
+		{"master", ""},
+		{"develop", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := TaskFromBranch(tt.branch); got != tt.want {
+			t.Errorf("TaskFromBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// Holiday is one day off from a calendar, such as a public holiday.
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// ParseICS reads the VEVENTs of an iCalendar file as holidays, one per day
+// covered. Only the date part of DTSTART/DTEND is used; DTEND is exclusive
+// as in all-day events.
+func ParseICS(r io.Reader) ([]Holiday, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Folded lines continue with a leading space or tab.
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var holidays []Holiday
+	var inEvent bool
+	var start, end time.Time
+	var name string
+	for _, line := range lines {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		prop, _, _ := strings.Cut(key, ";")
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, name = true, time.Time{}, time.Time{}, ""
+			}
+		case "DTSTART":
+			start = icsDate(value)
+		case "DTEND":
+			end = icsDate(value)
+		case "SUMMARY":
+			name = icsUnescape(value)
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				holidays = append(holidays, Holiday{Date: d, Name: name})
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// icsDate parses the date of "20261224" or "20261224T000000Z".
+func icsDate(value string) time.Time {
+	if len(value) < 8 {
+		return time.Time{}
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func icsUnescape(s string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(s)
+}
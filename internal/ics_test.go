@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseICS(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+		want []string // "yyyy-mm-dd name"
+	}{
+		{
+			name: "all-day event",
+			ics: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261225\r\nDTEND;VALUE=DATE:20261226\r\n" +
+				"SUMMARY:Christmas Day\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			want: []string{"2026-12-25 Christmas Day"},
+		},
+		{
+			name: "several days, end exclusive",
+			ics:  "BEGIN:VEVENT\nDTSTART:20261224\nDTEND:20261227\nSUMMARY:Break\nEND:VEVENT\n",
+			want: []string{"2026-12-24 Break", "2026-12-25 Break", "2026-12-26 Break"},
+		},
+		{
+			name: "missing end is one day",
+			ics:  "BEGIN:VEVENT\nDTSTART:20261003T000000Z\nSUMMARY:Unity Day\nEND:VEVENT\n",
+			want: []string{"2026-10-03 Unity Day"},
+		},
+		{
+			name: "folded and escaped summary",
+			ics:  "BEGIN:VEVENT\nDTSTART:20260101\nSUMMARY:New Year\\, \n Day\\; observed\nEND:VEVENT\n",
+			want: []string{"2026-01-01 New Year, Day; observed"},
+		},
+		{
+			name: "no start is skipped",
+			ics:  "BEGIN:VEVENT\nSUMMARY:Nothing\nEND:VEVENT\nBEGIN:VEVENT\nDTSTART:20260501\nSUMMARY:Labour Day\nEND:VEVENT\n",
+			want: []string{"2026-05-01 Labour Day"},
+		},
+		{
+			name: "properties outside events are ignored",
+			ics:  "BEGIN:VCALENDAR\nDTSTART:20260101\nEND:VCALENDAR\n",
+		},
+	}
+	for _, tt := range tests {
+		holidays, err := ParseICS(strings.NewReader(tt.ics))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, h := range holidays {
+			got = append(got, h.Date.Format("2006-01-02")+" "+h.Name)
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: ParseICS = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package invoice
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+var Formats = []string{"md", "html", "pdf"}
+
+type Item struct {
+	Date  time.Time
+	Task  string
+	Hours float64
+}
+
+type Line struct {
+	Bucket string
+	Hours  float64
+	Rate   float64
+	Amount float64
+	Items  []Item
+}
+
+type Invoice struct {
+	Number    string
+	Client    string
+	Employee  string
+	Period    time.Time
+	IssueDate time.Time
+	Currency  string
+	Lines     []Line
+	Hours     float64
+	Total     float64
+}
+
+// Render executes the invoice template for format. A custom template path
+// wins over <configDir>/templates/invoice.<format>.tmpl, which wins over the
+// built-in one. PDF output is rendered from the Markdown template.
+func Render(inv *Invoice, format, customPath, configDir string) ([]byte, error) {
+	tmplFormat := format
+	if format == "pdf" {
+		tmplFormat = "md"
+	}
+
+	src, err := loadTemplate(tmplFormat, customPath, configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := map[string]any{
+		"money": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+		"hours": func(v float64) string { return fmt.Sprintf("%.2f", v) },
+		"date":  func(t time.Time) string { return t.Format("02 Jan 2006") },
+		"month": func(t time.Time) string { return t.Format("January 2006") },
+	}
+
+	var buf bytes.Buffer
+	switch tmplFormat {
+	case "html":
+		t, err := htmltemplate.New("invoice").Funcs(funcs).Parse(src)
+		if err != nil {
+			return nil, err
+		}
+		err = t.Execute(&buf, inv)
+		if err != nil {
+			return nil, err
+		}
+	case "md":
+		t, err := texttemplate.New("invoice").Funcs(funcs).Parse(src)
+		if err != nil {
+			return nil, err
+		}
+		err = t.Execute(&buf, inv)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q (use %s)", format, strings.Join(Formats, ", "))
+	}
+
+	if format == "pdf" {
+		return textToPDF(buf.String()), nil
+	}
+	return buf.Bytes(), nil
+}
+
+func loadTemplate(format, customPath, configDir string) (string, error) {
+	if customPath != "" {
+		data, err := os.ReadFile(customPath)
+		return string(data), err
+	}
+
+	userPath := filepath.Join(configDir, "templates", "invoice."+format+".tmpl")
+	if data, err := os.ReadFile(userPath); err == nil {
+		return string(data), nil
+	}
+
+	data, err := defaultTemplates.ReadFile("templates/invoice." + format + ".tmpl")
+	return string(data), err
+}
@@ -0,0 +1,73 @@
+package invoice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+// sequenceLockWait bounds the wait for another invoice run to finish.
+const sequenceLockWait = 30 * time.Second
+
+type sequence struct {
+	Prefix string `json:"prefix"`
+	Next   int    `json:"next"`
+}
+
+func sequencePath(configDir string) string {
+	return filepath.Join(configDir, "invoices.json")
+}
+
+// LockSequence is held from PeekNumber to CommitNumber so two invoice runs
+// never hand out the same number.
+func LockSequence(configDir string) (release func(), err error) {
+	return internal.LockFile(filepath.Join(configDir, "invoices.lock"), sequenceLockWait)
+}
+
+func loadSequence(configDir string) (*sequence, error) {
+	seq := &sequence{Prefix: "INV-", Next: 1}
+	data, err := os.ReadFile(sequencePath(configDir))
+	if os.IsNotExist(err) {
+		return seq, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, seq); err != nil {
+		return nil, fmt.Errorf("corrupt invoice sequence %s: %v", sequencePath(configDir), err)
+	}
+	return seq, nil
+}
+
+func format(seq *sequence) string {
+	return fmt.Sprintf("%s%04d", seq.Prefix, seq.Next)
+}
+
+// PeekNumber returns the invoice number the next CommitNumber will consume.
+func PeekNumber(configDir string) (string, error) {
+	seq, err := loadSequence(configDir)
+	if err != nil {
+		return "", err
+	}
+	return format(seq), nil
+}
+
+// CommitNumber consumes the next invoice number once an invoice was written.
+// The caller holds LockSequence.
+func CommitNumber(configDir string) error {
+	seq, err := loadSequence(configDir)
+	if err != nil {
+		return err
+	}
+	seq.Next++
+
+	data, err := json.MarshalIndent(seq, "", "  ")
+	if err != nil {
+		return err
+	}
+	return internal.WriteFileAtomic(sequencePath(configDir), data, 0644)
+}
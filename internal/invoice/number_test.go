@@ -0,0 +1,108 @@
+package invoice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/srikanth-karthi/timesheet/internal"
+)
+
+func TestNumberSequence(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, want := range []string{"INV-0001", "INV-0002", "INV-0003"} {
+		// Peeking twice hands out the same number until it is committed.
+		for i := 0; i < 2; i++ {
+			if got, err := PeekNumber(dir); err != nil || got != want {
+				t.Fatalf("PeekNumber = %q, %v; want %q", got, err, want)
+			}
+		}
+		if err := CommitNumber(dir); err != nil {
+			t.Fatalf("CommitNumber: %v", err)
+		}
+	}
+}
+
+func TestNumberCustomPrefix(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(sequencePath(dir), []byte(`{"prefix": "ACME-", "next": 41}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CommitNumber(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := PeekNumber(dir); err != nil || got != "ACME-0042" {
+		t.Errorf("PeekNumber = %q, %v; want ACME-0042", got, err)
+	}
+}
+
+func TestNumberCorruptSequence(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "invoices.json"), []byte(`{"next": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PeekNumber(dir); err == nil {
+		t.Error("PeekNumber on a corrupt sequence succeeded")
+	}
+	if err := CommitNumber(dir); err == nil {
+		t.Error("CommitNumber on a corrupt sequence succeeded")
+	}
+}
+
+func TestLockSequence(t *testing.T) {
+	dir := t.TempDir()
+	release, err := LockSequence(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := internal.LockFile(filepath.Join(dir, "invoices.lock"), 0); !errors.Is(err, internal.ErrLocked) {
+		t.Errorf("second lock = %v, want ErrLocked", err)
+	}
+	release()
+}
+
+func TestNumberConcurrentRuns(t *testing.T) {
+	dir := t.TempDir()
+
+	const n = 10
+	numbers := make(chan string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := LockSequence(dir)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+			number, err := PeekNumber(dir)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := CommitNumber(dir); err != nil {
+				t.Error(err)
+				return
+			}
+			numbers <- number
+		}()
+	}
+	wg.Wait()
+	close(numbers)
+
+	seen := map[string]bool{}
+	for number := range numbers {
+		if seen[number] {
+			t.Errorf("%s handed out twice", number)
+		}
+		seen[number] = true
+	}
+	if len(seen) != n {
+		t.Errorf("%d distinct numbers, want %d", len(seen), n)
+	}
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned when another timesheet process holds a lock for
+// longer than the caller was willing to wait.
+var ErrLocked = errors.New("another timesheet command is using the local state")
+
+// lockPoll is how often a busy lock is retried.
+const lockPoll = 50 * time.Millisecond
+
+// fileLock is an advisory lock on a file in the config directory. It is
+// released when the process exits, so a crashed command never leaves it
+// held.
+type fileLock struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// acquireLock takes the lock on path, waiting up to wait for another
+// process to release it.
+func acquireLock(path string, wait time.Duration) (*fileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return &fileLock{f: f}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, ErrLocked
+		}
+		time.Sleep(lockPoll)
+	}
+}
+
+// release unlocks; calling it again, also from another goroutine, is a
+// no-op.
+func (l *fileLock) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	unlockFile(l.f)
+	l.f.Close()
+	l.f = nil
+}
+
+// LockFile takes an advisory lock on path for state kept outside meta.json,
+// waiting up to wait and returning ErrLocked after that.
+func LockFile(path string, wait time.Duration) (release func(), err error) {
+	lock, err := acquireLock(path, wait)
+	if err != nil {
+		return nil, err
+	}
+	return lock.release, nil
+}
+
+// WriteFileAtomic is writeFileAtomic for other packages.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return writeFileAtomic(path, data, perm)
+}
+
+// writeFileAtomic replaces path with data so that readers see either the
+// old or the new content, never a partial write.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
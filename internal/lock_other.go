@@ -0,0 +1,12 @@
+//go:build !unix && !windows
+
+package internal
+
+import "os"
+
+// Platforms without file locks run unlocked; writes are still atomic.
+func tryLockFile(f *os.File) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(f *os.File) {}
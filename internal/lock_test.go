@@ -0,0 +1,157 @@
+//go:build unix || windows
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// useTempConfig points meta.json and its locks at a fresh directory.
+func useTempConfig(t *testing.T) string {
+	dir := t.TempDir()
+	saved := []string{metaPath, metaLockPath, timerLockPath}
+	metaPath = filepath.Join(dir, "meta.json")
+	metaLockPath = filepath.Join(dir, "meta.lock")
+	timerLockPath = filepath.Join(dir, "timers.lock")
+	t.Cleanup(func() {
+		metaPath, metaLockPath, timerLockPath = saved[0], saved[1], saved[2]
+	})
+	return dir
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub", "state.json")
+
+	for _, content := range []string{"first", "second, longer content", "3"} {
+		if err := writeFileAtomic(path, []byte(content), 0600); err != nil {
+			t.Fatalf("writeFileAtomic: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != content {
+			t.Fatalf("read %q (%v), want %q", data, err, content)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+	if info, err := os.Stat(path); err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestAcquireLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	held, err := acquireLock(path, 0)
+	if err != nil {
+		t.Fatalf("first lock: %v", err)
+	}
+	if _, err := acquireLock(path, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("second lock without waiting: %v, want ErrLocked", err)
+	}
+
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		held.release()
+		close(released)
+	}()
+	waited, err := acquireLock(path, 5*time.Second)
+	if err != nil {
+		t.Fatalf("lock after release: %v", err)
+	}
+	<-released
+	waited.release()
+	// A second release is a no-op.
+	waited.release()
+}
+
+func TestUpdateMetaConcurrent(t *testing.T) {
+	useTempConfig(t)
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- UpdateMeta(func(m *Meta) error {
+				m.StartTimer(fmt.Sprintf("t%d", i), Timer{Start: "2026-10-12T09:00:00Z"})
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("UpdateMeta: %v", err)
+		}
+	}
+
+	meta, err := LoadMeta()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.Timers) != n {
+		t.Errorf("%d timers saved, want %d", len(meta.Timers), n)
+	}
+}
+
+func TestUpdateMetaFailedChange(t *testing.T) {
+	useTempConfig(t)
+	if err := SaveMeta(&Meta{Active: "ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	boom := errors.New("boom")
+	err := UpdateMeta(func(m *Meta) error {
+		m.Active = "changed"
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("UpdateMeta = %v, want %v", err, boom)
+	}
+	if meta, _ := LoadMeta(); meta.Active != "ops" {
+		t.Errorf("active = %q after a failed change, want ops", meta.Active)
+	}
+}
+
+func TestLoadMetaCorrupt(t *testing.T) {
+	dir := useTempConfig(t)
+	if err := os.WriteFile(metaPath, []byte(`{"active": "ops", "timers": {`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := LoadMeta()
+	var corrupt *CorruptMetaError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("LoadMeta = %v, want *CorruptMetaError", err)
+	}
+	if meta == nil || meta.Active != "general" {
+		t.Errorf("meta = %+v, want the defaults", meta)
+	}
+	if data, err := os.ReadFile(corrupt.Backup); err != nil || len(data) == 0 {
+		t.Errorf("backup %s not kept: %v", corrupt.Backup, err)
+	}
+	if filepath.Dir(corrupt.Backup) != dir {
+		t.Errorf("backup %s outside the config directory", corrupt.Backup)
+	}
+	if _, err := LoadMeta(); err != nil {
+		t.Errorf("LoadMeta after backup: %v", err)
+	}
+}
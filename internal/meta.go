@@ -2,32 +2,219 @@ package internal
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 )
 
+// DefaultTimer is the timer id used when 'start' and 'stop' get no --id.
+const DefaultTimer = "default"
+
+// A timer is saved as TimerPending before 'start' appends its row and as
+// TimerStopping before 'stop' writes its hours, so a command that dies half
+// way leaves a marker the next command can reconcile against the sheet.
+// Running timers whose row is in the sheet have an empty State.
+const (
+	TimerPending  = "pending"
+	TimerStopping = "stopping"
+)
+
+// Timer is one running session. Its row in the sheet is the one whose
+// timestamp equals Start.
+type Timer struct {
+	Start  string `json:"start"`
+	Bucket string `json:"bucket,omitempty"`
+	Task   string `json:"task,omitempty"`
+	State  string `json:"state,omitempty"`
+	// End is the time a stopping timer is booked up to.
+	End string `json:"end,omitempty"`
+	// Previous is the session a 'switch' replaced with this timer, kept
+	// (as stopping) until its hours are written.
+	Previous *Timer `json:"previous,omitempty"`
+}
+
 type Meta struct {
-	Active       string `json:"active"`
-	SessionStart string `json:"session_start"`
+	Active string `json:"active"`
+	// SessionStart is the single session of older versions; LoadMeta moves
+	// it into Timers.
+	SessionStart   string           `json:"session_start,omitempty"`
+	Timers         map[string]Timer `json:"timers,omitempty"`
+	LastInvocation string           `json:"last_invocation,omitempty"`
+}
+
+func (m *Meta) StartTimer(id string, t Timer) {
+	if m.Timers == nil {
+		m.Timers = map[string]Timer{}
+	}
+	m.Timers[id] = t
+}
+
+func (m *Meta) StopTimer(id string) {
+	delete(m.Timers, id)
+}
+
+// CommitTimer marks a pending timer as running once its row is written.
+func (m *Meta) CommitTimer(id string) {
+	if t, ok := m.Timers[id]; ok {
+		t.State = ""
+		m.Timers[id] = t
+	}
+}
+
+// Unsettled returns the ids of pending and stopping timers and of timers
+// whose replaced session is not booked yet, oldest first.
+func (m *Meta) Unsettled() []string {
+	var ids []string
+	for _, id := range m.TimerIDs() {
+		if t := m.Timers[id]; t.State != "" || t.Previous != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
-var metaPath = filepath.Join(os.Getenv("HOME"), ".timesheet", "meta.json")
+// TimerIDs returns the running timer ids, oldest first.
+func (m *Meta) TimerIDs() []string {
+	ids := make([]string, 0, len(m.Timers))
+	for id := range m.Timers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return m.Timers[ids[i]].Start < m.Timers[ids[j]].Start
+	})
+	return ids
+}
+
+var configDir = filepath.Join(os.Getenv("HOME"), ".timesheet")
+
+var metaPath = filepath.Join(configDir, "meta.json")
+
+// ConfigPath returns the path of a file inside the local config directory.
+func ConfigPath(name ...string) string {
+	return filepath.Join(append([]string{configDir}, name...)...)
+}
+
+var (
+	metaLockPath  = filepath.Join(configDir, "meta.lock")
+	timerLockPath = filepath.Join(configDir, "timers.lock")
+)
 
+// SetConfigDir moves the local config directory, with meta.json,
+// config.json and their locks, to dir. Tests use it to keep away from
+// the real ~/.timesheet.
+func SetConfigDir(dir string) {
+	configDir = dir
+	metaPath = filepath.Join(dir, "meta.json")
+	metaLockPath = filepath.Join(dir, "meta.lock")
+	timerLockPath = filepath.Join(dir, "timers.lock")
+	configPath = filepath.Join(dir, "config.json")
+}
+
+// metaLockWait bounds the wait for another process's read or write of
+// meta.json, which only takes as long as the file IO.
+const metaLockWait = 5 * time.Second
+
+// CorruptMetaError is returned by LoadMeta when meta.json is not valid
+// JSON. The file has been moved to Backup and a fresh Meta is returned.
+type CorruptMetaError struct {
+	Backup string
+	Err    error
+}
+
+func (e *CorruptMetaError) Error() string {
+	return fmt.Sprintf("%s was corrupted (%v); it was moved to %s and running timers in it are no longer tracked", metaPath, e.Err, e.Backup)
+}
+
+func (e *CorruptMetaError) Unwrap() error {
+	return e.Err
+}
+
+// LoadMeta reads meta.json. A missing file gives the defaults; a corrupted
+// one is set aside and reported with a *CorruptMetaError alongside the
+// defaults, so the timers it held are not lost without a trace.
 func LoadMeta() (*Meta, error) {
-	data, err := os.ReadFile(metaPath)
+	lock, err := acquireLock(metaLockPath, metaLockWait)
 	if err != nil {
+		return nil, err
+	}
+	defer lock.release()
+	return readMeta()
+}
+
+func readMeta() (*Meta, error) {
+	data, err := os.ReadFile(metaPath)
+	if os.IsNotExist(err) {
 		return &Meta{Active: "general"}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+
 	var meta Meta
-	err = json.Unmarshal(data, &meta)
-	return &meta, err
+	if err := json.Unmarshal(data, &meta); err != nil {
+		backup := metaPath + ".corrupt-" + time.Now().Format("20060102-150405")
+		if rerr := os.Rename(metaPath, backup); rerr != nil {
+			return nil, fmt.Errorf("%s is corrupted (%v) and could not be moved aside: %w", metaPath, err, rerr)
+		}
+		return &Meta{Active: "general"}, &CorruptMetaError{Backup: backup, Err: err}
+	}
+	if meta.SessionStart != "" {
+		if _, ok := meta.Timers[DefaultTimer]; !ok {
+			meta.StartTimer(DefaultTimer, Timer{Start: meta.SessionStart})
+		}
+		meta.SessionStart = ""
+	}
+	return &meta, nil
 }
 
+// SaveMeta replaces meta.json with meta. Prefer UpdateMeta, which does not
+// overwrite changes made by other processes since meta was loaded.
 func SaveMeta(meta *Meta) error {
-	os.MkdirAll(filepath.Dir(metaPath), 0755)
+	lock, err := acquireLock(metaLockPath, metaLockWait)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+	return writeMeta(meta)
+}
+
+func writeMeta(meta *Meta) error {
 	data, err := json.MarshalIndent(meta, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(metaPath, data, 0644)
+	return writeFileAtomic(metaPath, data, 0644)
+}
+
+// UpdateMeta applies change to the current meta.json and saves it while
+// holding the lock, so two terminals changing different timers do not
+// clobber each other. Nothing is saved when change fails.
+func UpdateMeta(change func(m *Meta) error) error {
+	lock, err := acquireLock(metaLockPath, metaLockWait)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	meta, err := readMeta()
+	if err != nil {
+		return err
+	}
+	if err := change(meta); err != nil {
+		return err
+	}
+	return writeMeta(meta)
+}
+
+// LockTimers is held while a command writes a timer's row to the sheet,
+// between saving the timer as pending or stopping and settling it. It waits
+// up to wait for another process and returns ErrLocked after that.
+func LockTimers(wait time.Duration) (release func(), err error) {
+	lock, err := acquireLock(timerLockPath, wait)
+	if err != nil {
+		return nil, err
+	}
+	return lock.release, nil
 }
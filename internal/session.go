@@ -1,31 +1,62 @@
 package internal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"strings"
 )
 
 var CurrentUserID string
 
+// The .session file holds the EMP ID on the first line and its session
+// token on the second. The token is checked against the admin tab on every
+// command, so the EMP ID alone does not log anyone in.
+const sessionPath = ".session"
+
 func IsLoggedIn() bool {
 	return GetSessionUser() != ""
 }
 
 func GetSessionUser() string {
-	data, err := os.ReadFile(".session")
+	empID, _ := readSession()
+	CurrentUserID = empID
+	return CurrentUserID
+}
+
+// GetSessionToken returns the token saved with the session.
+func GetSessionToken() string {
+	_, token := readSession()
+	return token
+}
+
+func readSession() (empID, token string) {
+	data, err := os.ReadFile(sessionPath)
 	if err != nil {
-		return ""
+		return "", ""
 	}
-	CurrentUserID = strings.TrimSpace(string(data))
-	return CurrentUserID
+	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 2)
+	empID = strings.TrimSpace(lines[0])
+	if len(lines) > 1 {
+		token = strings.TrimSpace(lines[1])
+	}
+	return empID, token
+}
+
+// SessionToken ties a session to the password it was opened with: a hand
+// written .session naming someone else does not match, and resetting the
+// password ends existing sessions.
+func SessionToken(empID, password string) string {
+	sum := sha256.Sum256([]byte(empID + "\x00" + password))
+	return hex.EncodeToString(sum[:])
 }
 
-func SaveSession(empID string) error {
+func SaveSession(empID, password string) error {
 	CurrentUserID = empID
-	return os.WriteFile(".session", []byte(empID), 0644)
+	return writeFileAtomic(sessionPath, []byte(empID+"\n"+SessionToken(empID, password)+"\n"), 0600)
 }
 
 func ClearSession() error {
 	CurrentUserID = ""
-	return os.Remove(".session")
+	return os.Remove(sessionPath)
 }
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"math"
+	"strings"
+)
+
+// a1Range is a parsed A1 range with 1-based bounds; open ends are math.MaxInt.
+type a1Range struct {
+	sheet      string
+	col1, row1 int
+	col2, row2 int
+}
+
+// parseA1 parses ranges such as "Sheet!A5:G", "Sheet!C1:Z1", "Sheet!E12"
+// or a bare "Sheet".
+func parseA1(rng string) a1Range {
+	sheet, cells, _ := strings.Cut(rng, "!")
+	r := a1Range{sheet: strings.Trim(sheet, "'"), col1: 1, row1: 1, col2: math.MaxInt, row2: math.MaxInt}
+	if cells == "" {
+		return r
+	}
+
+	first, second, isSpan := strings.Cut(cells, ":")
+	col, row := parseCell(first)
+	if col > 0 {
+		r.col1 = col
+	}
+	if row > 0 {
+		r.row1 = row
+	}
+	if !isSpan {
+		second = first
+	}
+
+	col, row = parseCell(second)
+	if col > 0 {
+		r.col2 = col
+	}
+	if row > 0 {
+		r.row2 = row
+	}
+	return r
+}
+
+// parseCell splits "AB12" into column 28 and row 12; missing parts are 0.
+func parseCell(cell string) (col, row int) {
+	i := 0
+	for ; i < len(cell) && cell[i] >= 'A' && cell[i] <= 'Z'; i++ {
+		col = col*26 + int(cell[i]-'A'+1)
+	}
+	for ; i < len(cell) && cell[i] >= '0' && cell[i] <= '9'; i++ {
+		row = row*10 + int(cell[i]-'0')
+	}
+	return col, row
+}
+
+func (a a1Range) overlaps(b a1Range) bool {
+	return a.sheet == b.sheet &&
+		a.col1 <= b.col2 && b.col1 <= a.col2 &&
+		a.row1 <= b.row2 && b.row1 <= a.row2
+}
+
+// appendTarget widens an append range to every row below its start, since
+// the API decides where the new rows land.
+func appendTarget(rng string) a1Range {
+	r := parseA1(rng)
+	r.row2 = math.MaxInt
+	return r
+}
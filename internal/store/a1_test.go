@@ -0,0 +1,61 @@
+package store
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseA1(t *testing.T) {
+	open := math.MaxInt
+	tests := []struct {
+		rng  string
+		want a1Range
+	}{
+		{"E1001", a1Range{sheet: "E1001", col1: 1, row1: 1, col2: open, row2: open}},
+		{"E1001!A5:J", a1Range{sheet: "E1001", col1: 1, row1: 5, col2: 10, row2: open}},
+		{"E1001!C1:Z1", a1Range{sheet: "E1001", col1: 3, row1: 1, col2: 26, row2: 1}},
+		{"E1001!E12", a1Range{sheet: "E1001", col1: 5, row1: 12, col2: 5, row2: 12}},
+		{"E1001!AB3:AC4", a1Range{sheet: "E1001", col1: 28, row1: 3, col2: 29, row2: 4}},
+		{"admin!A:E", a1Range{sheet: "admin", col1: 1, row1: 1, col2: 5, row2: open}},
+		{"'my sheet'!B2", a1Range{sheet: "my sheet", col1: 2, row1: 2, col2: 2, row2: 2}},
+	}
+	for _, tt := range tests {
+		if got := parseA1(tt.rng); got != tt.want {
+			t.Errorf("parseA1(%q) = %+v, want %+v", tt.rng, got, tt.want)
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"E1001!A5:J", "E1001!E12", true},
+		{"E1001!A5:J", "E1001!C1:Z1", false},
+		{"E1001!C1:Z1", "E1001!D1", true},
+		{"E1001!A5:J", "E1002!A5:J", false},
+		{"E1001", "E1001!E12", true},
+		{"E1001!A5:D", "E1001!E5:E", false},
+		{"E1001!A5:E5", "E1001!A6:E6", false},
+	}
+	for _, tt := range tests {
+		a, b := parseA1(tt.a), parseA1(tt.b)
+		if got := a.overlaps(b); got != tt.want {
+			t.Errorf("%q overlaps %q = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := b.overlaps(a); got != tt.want {
+			t.Errorf("%q overlaps %q = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestAppendTarget(t *testing.T) {
+	r := appendTarget("E1001!A3:J")
+	if !r.overlaps(parseA1("E1001!E40")) {
+		t.Errorf("append to A3:J should cover rows below it")
+	}
+	if r.overlaps(parseA1("E1001!C1:Z1")) {
+		t.Errorf("append to A3:J should not cover the bucket row")
+	}
+}
@@ -0,0 +1,116 @@
+// Package store is the CLI's access layer to the timesheet spreadsheet.
+// Commands talk to a Client, which is either a Direct connection to the
+// Sheets API or a Remote connection to a running 'timesheet daemon'.
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// Client is bound to one spreadsheet; ranges are in A1 notation.
+type Client interface {
+	Get(rng string) ([][]interface{}, error)
+	BatchGet(ranges []string) ([][][]interface{}, error)
+	Append(rng string, rows [][]interface{}, inputOption string) error
+	Update(rng string, rows [][]interface{}, inputOption string) error
+	BatchUpdate(data []*sheets.ValueRange, inputOption string) error
+	Spreadsheet() (*sheets.Spreadsheet, error)
+	BatchUpdateSpreadsheet(req *sheets.BatchUpdateSpreadsheetRequest) (*sheets.BatchUpdateSpreadsheetResponse, error)
+}
+
+// Direct calls the Sheets API on every request, retrying temporary
+// failures according to its RetryPolicy.
+type Direct struct {
+	srv           *sheets.Service
+	spreadsheetID string
+	retry         RetryPolicy
+}
+
+func NewDirect(srv *sheets.Service, spreadsheetID string) *Direct {
+	return &Direct{srv: srv, spreadsheetID: spreadsheetID, retry: DefaultRetryPolicy}
+}
+
+func (d *Direct) Get(rng string) ([][]interface{}, error) {
+	var values [][]interface{}
+	err := d.retry.retry("read "+rng, true, func(ctx context.Context) error {
+		resp, err := d.srv.Spreadsheets.Values.Get(d.spreadsheetID, rng).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		values = resp.Values
+		return nil
+	})
+	return values, err
+}
+
+func (d *Direct) BatchGet(ranges []string) ([][][]interface{}, error) {
+	var resp *sheets.BatchGetValuesResponse
+	err := d.retry.retry(fmt.Sprintf("read %d ranges", len(ranges)), true, func(ctx context.Context) error {
+		var err error
+		resp, err = d.srv.Spreadsheets.Values.BatchGet(d.spreadsheetID).Ranges(ranges...).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([][][]interface{}, len(ranges))
+	for i, vr := range resp.ValueRanges {
+		if i < len(out) {
+			out[i] = vr.Values
+		}
+	}
+	return out, nil
+}
+
+func (d *Direct) Append(rng string, rows [][]interface{}, inputOption string) error {
+	return d.retry.retry("append to "+rng, false, func(ctx context.Context) error {
+		_, err := d.srv.Spreadsheets.Values.Append(d.spreadsheetID, rng, &sheets.ValueRange{
+			Values: rows,
+		}).ValueInputOption(inputOption).InsertDataOption("INSERT_ROWS").Context(ctx).Do()
+		return err
+	})
+}
+
+func (d *Direct) Update(rng string, rows [][]interface{}, inputOption string) error {
+	return d.retry.retry("write "+rng, true, func(ctx context.Context) error {
+		_, err := d.srv.Spreadsheets.Values.Update(d.spreadsheetID, rng, &sheets.ValueRange{
+			Values: rows,
+		}).ValueInputOption(inputOption).Context(ctx).Do()
+		return err
+	})
+}
+
+func (d *Direct) BatchUpdate(data []*sheets.ValueRange, inputOption string) error {
+	return d.retry.retry(fmt.Sprintf("write %d ranges", len(data)), true, func(ctx context.Context) error {
+		_, err := d.srv.Spreadsheets.Values.BatchUpdate(d.spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: inputOption,
+			Data:             data,
+		}).Context(ctx).Do()
+		return err
+	})
+}
+
+func (d *Direct) Spreadsheet() (*sheets.Spreadsheet, error) {
+	var ss *sheets.Spreadsheet
+	err := d.retry.retry("read spreadsheet", true, func(ctx context.Context) error {
+		var err error
+		ss, err = d.srv.Spreadsheets.Get(d.spreadsheetID).Context(ctx).Do()
+		return err
+	})
+	return ss, err
+}
+
+// BatchUpdateSpreadsheet is not idempotent (adding a tab twice fails, a
+// chart is added twice), so it is retried like an append.
+func (d *Direct) BatchUpdateSpreadsheet(req *sheets.BatchUpdateSpreadsheetRequest) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	var resp *sheets.BatchUpdateSpreadsheetResponse
+	err := d.retry.retry("update spreadsheet", false, func(ctx context.Context) error {
+		var err error
+		resp, err = d.srv.Spreadsheets.BatchUpdate(d.spreadsheetID, req).Context(ctx).Do()
+		return err
+	})
+	return resp, err
+}
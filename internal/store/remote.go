@@ -0,0 +1,161 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+const (
+	dialTimeout = 200 * time.Millisecond
+	// statusTimeout bounds the liveness check, so a wedged daemon makes
+	// commands fall back to direct calls instead of hanging.
+	statusTimeout = 2 * time.Second
+	// callTimeout bounds every other call. It outlasts the daemon's own
+	// retries of a Sheets call plus waiting for an earlier flush.
+	callTimeout = 10 * time.Minute
+)
+
+// request and response are exchanged as one JSON object each per
+// connection on the daemon's Unix socket.
+type request struct {
+	Op          string                                `json:"op"`
+	Range       string                                `json:"range,omitempty"`
+	Ranges      []string                              `json:"ranges,omitempty"`
+	Rows        [][]interface{}                       `json:"rows,omitempty"`
+	InputOption string                                `json:"input_option,omitempty"`
+	Data        []*sheets.ValueRange                  `json:"data,omitempty"`
+	Batch       *sheets.BatchUpdateSpreadsheetRequest `json:"batch,omitempty"`
+}
+
+// A failed call carries Error; when it was a Sheets call, Op, Attempts and
+// Temporary rebuild the *Error on the client side.
+type response struct {
+	Error       string                                 `json:"error,omitempty"`
+	Op          string                                 `json:"op,omitempty"`
+	Attempts    int                                    `json:"attempts,omitempty"`
+	Temporary   bool                                   `json:"temporary,omitempty"`
+	Values      [][]interface{}                        `json:"values,omitempty"`
+	Multi       [][][]interface{}                      `json:"multi,omitempty"`
+	Spreadsheet *sheets.Spreadsheet                    `json:"spreadsheet,omitempty"`
+	Batch       *sheets.BatchUpdateSpreadsheetResponse `json:"batch,omitempty"`
+	Status      *Status                                `json:"status,omitempty"`
+}
+
+// Status describes a running daemon.
+type Status struct {
+	PID           int       `json:"pid"`
+	Started       time.Time `json:"started"`
+	CachedRanges  int       `json:"cached_ranges"`
+	PendingWrites int       `json:"pending_writes"`
+}
+
+// Remote forwards every call to a running daemon.
+type Remote struct {
+	socket string
+}
+
+// Dial returns a Remote if a daemon answers on socket.
+func Dial(socket string) (*Remote, bool) {
+	r := &Remote{socket: socket}
+	if _, err := r.Status(); err != nil {
+		return nil, false
+	}
+	return r, true
+}
+
+func (r *Remote) call(req request) (*response, error) {
+	conn, err := net.DialTimeout("unix", r.socket, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	timeout := callTimeout
+	if req.Op == "status" {
+		timeout = statusTimeout
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		err := errors.New(resp.Error)
+		if resp.Op != "" {
+			return nil, &Error{Op: resp.Op, Attempts: resp.Attempts, Temporary: resp.Temporary, Err: err}
+		}
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (r *Remote) Status() (*Status, error) {
+	resp, err := r.call(request{Op: "status"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Status, nil
+}
+
+// Shutdown asks the daemon to flush pending writes and exit.
+func (r *Remote) Shutdown() error {
+	_, err := r.call(request{Op: "shutdown"})
+	return err
+}
+
+func (r *Remote) Get(rng string) ([][]interface{}, error) {
+	resp, err := r.call(request{Op: "get", Range: rng})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Values, nil
+}
+
+func (r *Remote) BatchGet(ranges []string) ([][][]interface{}, error) {
+	resp, err := r.call(request{Op: "batch_get", Ranges: ranges})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Multi, nil
+}
+
+func (r *Remote) Append(rng string, rows [][]interface{}, inputOption string) error {
+	_, err := r.call(request{Op: "append", Range: rng, Rows: rows, InputOption: inputOption})
+	return err
+}
+
+func (r *Remote) Update(rng string, rows [][]interface{}, inputOption string) error {
+	_, err := r.call(request{Op: "update", Range: rng, Rows: rows, InputOption: inputOption})
+	return err
+}
+
+func (r *Remote) BatchUpdate(data []*sheets.ValueRange, inputOption string) error {
+	_, err := r.call(request{Op: "batch_update", Data: data, InputOption: inputOption})
+	return err
+}
+
+func (r *Remote) Spreadsheet() (*sheets.Spreadsheet, error) {
+	resp, err := r.call(request{Op: "spreadsheet"})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Spreadsheet, nil
+}
+
+func (r *Remote) BatchUpdateSpreadsheet(req *sheets.BatchUpdateSpreadsheetRequest) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	resp, err := r.call(request{Op: "batch_update_spreadsheet", Batch: req})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Batch, nil
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// RetryPolicy controls how Direct retries failed Sheets calls.
+type RetryPolicy struct {
+	// Attempts is the total number of tries per call.
+	Attempts int
+	// Timeout bounds each single try.
+	Timeout time.Duration
+	// BaseDelay is the first backoff, doubled per retry up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// QuotaDelay is the least wait after a quota error; Sheets quotas
+	// refill per minute, so short backoffs only burn attempts.
+	QuotaDelay time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:   5,
+	Timeout:    30 * time.Second,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+	QuotaDelay: 10 * time.Second,
+}
+
+// Error is a failed Sheets call. Temporary errors (rate limits, outages,
+// timeouts) were retried until the policy gave up; the rest, such as a bad
+// range or missing permission, fail on the first try.
+type Error struct {
+	Op        string
+	Attempts  int
+	Temporary bool
+	Err       error
+}
+
+func (e *Error) Error() string {
+	if e.Temporary {
+		return fmt.Sprintf("%s: Google Sheets is temporarily unavailable, gave up after %d attempts (try again shortly): %v", e.Op, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Op, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// retry runs call until it succeeds, fails permanently or the policy runs
+// out of attempts. Calls that are not idempotent, such as appends, are only
+// retried when the API rejected them outright (429, 503) and never after a
+// timeout, which may have applied the write already.
+func (p RetryPolicy) retry(op string, idempotent bool, call func(ctx context.Context) error) error {
+	attempts := p.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+		err = call(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		temporary, rejected := classify(err)
+		if !temporary {
+			return &Error{Op: op, Attempts: attempt, Err: err}
+		}
+		if attempt >= attempts || (!idempotent && !rejected) {
+			return &Error{Op: op, Attempts: attempt, Temporary: true, Err: err}
+		}
+		time.Sleep(p.delay(attempt, err))
+	}
+}
+
+// classify reports whether err is worth retrying and whether the API
+// rejected the request without applying it.
+func classify(err error) (temporary, rejected bool) {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true, true
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+			return true, false
+		}
+		return false, false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true, false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true, false
+	}
+	return false, false
+}
+
+// delay is the wait before the next attempt: the server's Retry-After if
+// given, else exponential backoff with full jitter, at least QuotaDelay
+// for rate limits.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Header != nil {
+		if secs, perr := strconv.Atoi(apiErr.Header.Get("Retry-After")); perr == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	wait := time.Duration(rand.Int63n(int64(backoff) + 1))
+
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusTooManyRequests && wait < p.QuotaDelay {
+		wait = p.QuotaDelay + time.Duration(rand.Int63n(int64(p.QuotaDelay)/2+1))
+	}
+	return wait
+}
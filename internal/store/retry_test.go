@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name                string
+		err                 error
+		temporary, rejected bool
+	}{
+		{"rate limit", &googleapi.Error{Code: http.StatusTooManyRequests}, true, true},
+		{"unavailable", &googleapi.Error{Code: http.StatusServiceUnavailable}, true, true},
+		{"internal", &googleapi.Error{Code: http.StatusInternalServerError}, true, false},
+		{"bad gateway", &googleapi.Error{Code: http.StatusBadGateway}, true, false},
+		{"gateway timeout", &googleapi.Error{Code: http.StatusGatewayTimeout}, true, false},
+		{"bad request", &googleapi.Error{Code: http.StatusBadRequest}, false, false},
+		{"forbidden", &googleapi.Error{Code: http.StatusForbidden}, false, false},
+		{"wrapped", fmt.Errorf("get: %w", &googleapi.Error{Code: http.StatusTooManyRequests}), true, true},
+		{"deadline", context.DeadlineExceeded, true, false},
+		{"network", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true, false},
+		{"other", errors.New("boom"), false, false},
+	}
+	for _, tt := range tests {
+		temporary, rejected := classify(tt.err)
+		if temporary != tt.temporary || rejected != tt.rejected {
+			t.Errorf("%s: classify = (%v, %v), want (%v, %v)", tt.name, temporary, rejected, tt.temporary, tt.rejected)
+		}
+	}
+}
+
+func TestDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 8 * time.Second, QuotaDelay: 10 * time.Second}
+	outage := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	quota := &googleapi.Error{Code: http.StatusTooManyRequests}
+	retryAfter := &googleapi.Error{Code: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"7"}}}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		err      error
+		min, max time.Duration
+	}{
+		{"first backoff", 1, outage, 0, time.Second},
+		{"doubled", 3, outage, 0, 4 * time.Second},
+		{"capped", 10, outage, 0, 8 * time.Second},
+		{"overflow capped", 80, outage, 0, 8 * time.Second},
+		{"quota floor", 1, quota, 10 * time.Second, 15 * time.Second},
+		{"retry-after", 1, retryAfter, 7 * time.Second, 7 * time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			if got := p.delay(tt.attempt, tt.err); got < tt.min || got > tt.max {
+				t.Errorf("%s: delay = %v, want within [%v, %v]", tt.name, got, tt.min, tt.max)
+				break
+			}
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	p := RetryPolicy{Attempts: 3, Timeout: time.Second, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	tests := []struct {
+		name       string
+		idempotent bool
+		err        error
+		calls      int
+		temporary  bool
+	}{
+		{"permanent", true, &googleapi.Error{Code: http.StatusBadRequest}, 1, false},
+		{"temporary read", true, &googleapi.Error{Code: http.StatusInternalServerError}, 3, true},
+		{"rejected append", false, &googleapi.Error{Code: http.StatusServiceUnavailable}, 3, true},
+		{"append that may have landed", false, &googleapi.Error{Code: http.StatusBadGateway}, 1, true},
+	}
+	for _, tt := range tests {
+		calls := 0
+		err := p.retry("op", tt.idempotent, func(ctx context.Context) error {
+			calls++
+			return tt.err
+		})
+		var sheetsErr *Error
+		if !errors.As(err, &sheetsErr) {
+			t.Fatalf("%s: retry returned %v, want *Error", tt.name, err)
+		}
+		if calls != tt.calls || sheetsErr.Attempts != tt.calls || sheetsErr.Temporary != tt.temporary {
+			t.Errorf("%s: %d calls, %+v; want %d calls, temporary %v", tt.name, calls, sheetsErr, tt.calls, tt.temporary)
+		}
+	}
+}
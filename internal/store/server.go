@@ -0,0 +1,434 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+type cacheEntry struct {
+	values  [][]interface{}
+	target  a1Range
+	fetched time.Time
+}
+
+type pendingWrite struct {
+	append  bool
+	rng     string
+	rows    [][]interface{}
+	data    []*sheets.ValueRange
+	input   string
+	targets []a1Range
+	result  chan error
+}
+
+// Server keeps one authenticated backend, caches reads of the user's own
+// sheet and batches writes to it for CLI invocations connecting over a Unix
+// socket. Shared tabs (admin, submissions, approvals, ...) are changed from
+// other machines, so their reads and writes pass straight through. Writes
+// are held for at most one flush interval and callers wait for the flush,
+// so errors still reach the command that caused them. A read overlapping a
+// pending write flushes first, and each flush drops the cached ranges it
+// touched.
+//
+// Backend calls never run under mu, so a slow or retrying call only holds
+// up the requests that depend on it.
+type Server struct {
+	backend       Client
+	userSheet     string
+	flushInterval time.Duration
+	cacheTTL      time.Duration
+
+	// flushMu serializes flushes so writes reach the backend in order.
+	flushMu sync.Mutex
+
+	mu sync.Mutex
+	// gen counts invalidations; a read only caches what it fetched if no
+	// write landed in the meantime.
+	gen      uint64
+	cache    map[string]cacheEntry
+	pending  []*pendingWrite
+	inflight []*pendingWrite
+	started  time.Time
+	listener net.Listener
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// NewServer returns a server caching reads of userSheet. With an empty
+// userSheet nothing is cached.
+func NewServer(backend Client, userSheet string, flushInterval, cacheTTL time.Duration) *Server {
+	return &Server{
+		backend:       backend,
+		userSheet:     userSheet,
+		flushInterval: flushInterval,
+		cacheTTL:      cacheTTL,
+		cache:         map[string]cacheEntry{},
+		closed:        make(chan struct{}),
+	}
+}
+
+// Serve listens on socket until Close is called or a client sends shutdown.
+// The socket's directory is restricted to the owner before listening, so
+// the socket is never reachable by other local users.
+func (s *Server) Serve(socket string) error {
+	if r, ok := Dial(socket); ok {
+		st, _ := r.Status()
+		return fmt.Errorf("daemon already running (pid %d)", st.PID)
+	}
+
+	dir := filepath.Dir(socket)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if err := os.Chmod(dir, 0700); err != nil {
+		return err
+	}
+	os.Remove(socket)
+
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		l.Close()
+		return err
+	}
+	s.listener = l
+	s.started = time.Now()
+
+	go s.flushLoop()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-s.closed:
+				return nil
+			default:
+				return err
+			}
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close stops accepting connections and flushes pending writes.
+func (s *Server) Close() {
+	s.once.Do(func() {
+		close(s.closed)
+		if s.listener != nil {
+			s.listener.Close()
+		}
+		s.flush()
+	})
+}
+
+func (s *Server) flushLoop() {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var req request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+
+	resp := s.dispatch(req)
+	json.NewEncoder(conn).Encode(resp)
+
+	if req.Op == "shutdown" {
+		go s.Close()
+	}
+}
+
+func (s *Server) dispatch(req request) *response {
+	resp := &response{}
+	var err error
+
+	switch req.Op {
+	case "status":
+		s.mu.Lock()
+		resp.Status = &Status{PID: os.Getpid(), Started: s.started, CachedRanges: len(s.cache), PendingWrites: len(s.pending) + len(s.inflight)}
+		s.mu.Unlock()
+	case "shutdown":
+	case "get":
+		resp.Values, err = s.get(req.Range)
+	case "batch_get":
+		resp.Multi, err = s.batchGet(req.Ranges)
+	case "append":
+		w := &pendingWrite{append: true, rng: req.Range, rows: req.Rows, input: req.InputOption, targets: []a1Range{appendTarget(req.Range)}}
+		if s.owned(w.targets) {
+			err = s.enqueue(w)
+		} else {
+			err = s.backend.Append(req.Range, req.Rows, req.InputOption)
+		}
+	case "update":
+		w := &pendingWrite{data: []*sheets.ValueRange{{Range: req.Range, Values: req.Rows}}, input: req.InputOption, targets: []a1Range{parseA1(req.Range)}}
+		if s.owned(w.targets) {
+			err = s.enqueue(w)
+		} else {
+			err = s.backend.Update(req.Range, req.Rows, req.InputOption)
+		}
+	case "batch_update":
+		w := &pendingWrite{data: req.Data, input: req.InputOption}
+		for _, d := range req.Data {
+			w.targets = append(w.targets, parseA1(d.Range))
+		}
+		if s.owned(w.targets) {
+			err = s.enqueue(w)
+		} else {
+			err = s.backend.BatchUpdate(req.Data, req.InputOption)
+		}
+	case "spreadsheet":
+		resp.Spreadsheet, err = s.backend.Spreadsheet()
+	case "batch_update_spreadsheet":
+		resp.Batch, err = s.batchUpdateSpreadsheet(req.Batch)
+	default:
+		err = fmt.Errorf("unknown op %q", req.Op)
+	}
+
+	sheetsErr, isSheets := err.(*Error)
+	switch {
+	case isSheets:
+		resp.Error = sheetsErr.Err.Error()
+		resp.Op, resp.Attempts, resp.Temporary = sheetsErr.Op, sheetsErr.Attempts, sheetsErr.Temporary
+	case err != nil:
+		resp.Error = err.Error()
+	}
+	return resp
+}
+
+// owned reports whether all targets lie in the user's sheet, the only one
+// the daemon caches and batches.
+func (s *Server) owned(targets []a1Range) bool {
+	if s.userSheet == "" {
+		return false
+	}
+	for _, t := range targets {
+		if t.sheet != s.userSheet {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) enqueue(w *pendingWrite) error {
+	w.result = make(chan error, 1)
+	s.mu.Lock()
+	s.pending = append(s.pending, w)
+	s.mu.Unlock()
+
+	select {
+	case <-s.closed:
+		s.flush()
+	default:
+	}
+	return <-w.result
+}
+
+// flush sends pending writes in order, coalescing runs of appends to the
+// same range into one Append and runs of updates into one BatchUpdate.
+func (s *Server) flush() {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.inflight = pending
+	s.mu.Unlock()
+
+	for len(pending) > 0 {
+		first := pending[0]
+		n := 1
+		for n < len(pending) && pending[n].append == first.append && pending[n].input == first.input &&
+			(!first.append || pending[n].rng == first.rng) {
+			n++
+		}
+		run := pending[:n]
+		pending = pending[n:]
+
+		var err error
+		if first.append {
+			var rows [][]interface{}
+			for _, w := range run {
+				rows = append(rows, w.rows...)
+			}
+			err = s.backend.Append(first.rng, rows, first.input)
+		} else {
+			var data []*sheets.ValueRange
+			for _, w := range run {
+				data = append(data, w.data...)
+			}
+			err = s.backend.BatchUpdate(data, first.input)
+		}
+		if err != nil {
+			log.Printf("⚠️ Flush of %d writes failed: %v", len(run), err)
+		}
+
+		s.mu.Lock()
+		for _, w := range run {
+			s.invalidateLocked(w.targets)
+		}
+		s.inflight = pending
+		s.mu.Unlock()
+		for _, w := range run {
+			w.result <- err
+		}
+	}
+}
+
+func (s *Server) invalidateLocked(targets []a1Range) {
+	s.gen++
+	for key, entry := range s.cache {
+		for _, t := range targets {
+			if entry.target.overlaps(t) {
+				delete(s.cache, key)
+				break
+			}
+		}
+	}
+}
+
+// unflushedOverlapsLocked reports whether a queued or in-flight write
+// touches target.
+func (s *Server) unflushedOverlapsLocked(target a1Range) bool {
+	for _, writes := range [][]*pendingWrite{s.pending, s.inflight} {
+		for _, w := range writes {
+			for _, t := range w.targets {
+				if t.overlaps(target) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) cachedLocked(rng string) ([][]interface{}, bool) {
+	entry, ok := s.cache[rng]
+	if !ok || time.Since(entry.fetched) > s.cacheTTL {
+		return nil, false
+	}
+	return entry.values, true
+}
+
+// settle flushes first if a write to any of ranges has not reached the
+// backend yet, so the read sees it.
+func (s *Server) settle(ranges []string) {
+	s.mu.Lock()
+	overlap := false
+	for _, rng := range ranges {
+		if s.unflushedOverlapsLocked(parseA1(rng)) {
+			overlap = true
+			break
+		}
+	}
+	s.mu.Unlock()
+	if overlap {
+		s.flush()
+	}
+}
+
+func (s *Server) get(rng string) ([][]interface{}, error) {
+	target := parseA1(rng)
+	if !s.owned([]a1Range{target}) {
+		return s.backend.Get(rng)
+	}
+	s.settle([]string{rng})
+
+	s.mu.Lock()
+	if values, ok := s.cachedLocked(rng); ok {
+		s.mu.Unlock()
+		return values, nil
+	}
+	gen := s.gen
+	s.mu.Unlock()
+
+	values, err := s.backend.Get(rng)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.gen == gen {
+		s.cache[rng] = cacheEntry{values: values, target: target, fetched: time.Now()}
+	}
+	s.mu.Unlock()
+	return values, nil
+}
+
+func (s *Server) batchGet(ranges []string) ([][][]interface{}, error) {
+	s.settle(ranges)
+
+	out := make([][][]interface{}, len(ranges))
+	var missing []string
+	var missingIdx []int
+	s.mu.Lock()
+	for i, rng := range ranges {
+		if s.owned([]a1Range{parseA1(rng)}) {
+			if values, ok := s.cachedLocked(rng); ok {
+				out[i] = values
+				continue
+			}
+		}
+		missing = append(missing, rng)
+		missingIdx = append(missingIdx, i)
+	}
+	gen := s.gen
+	s.mu.Unlock()
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	fetched, err := s.backend.BatchGet(missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(fetched) != len(missing) {
+		return nil, errors.New("batch get returned a different number of ranges")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for j, values := range fetched {
+		out[missingIdx[j]] = values
+		target := parseA1(missing[j])
+		if s.gen == gen && s.owned([]a1Range{target}) {
+			s.cache[missing[j]] = cacheEntry{values: values, target: target, fetched: time.Now()}
+		}
+	}
+	return out, nil
+}
+
+// batchUpdateSpreadsheet changes structure (tabs, formatting), so it runs
+// after pending writes and drops every cached read.
+func (s *Server) batchUpdateSpreadsheet(req *sheets.BatchUpdateSpreadsheetRequest) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	s.flush()
+	resp, err := s.backend.BatchUpdateSpreadsheet(req)
+
+	s.mu.Lock()
+	s.gen++
+	s.cache = map[string]cacheEntry{}
+	s.mu.Unlock()
+	return resp, err
+}
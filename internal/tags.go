@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+var hashtagPattern = regexp.MustCompile(`(?:^|\s)#([A-Za-z][\w-]*)`)
+
+// ParseTags returns the #hashtags found in a task description, lowercased
+// and without the leading '#'.
+func ParseTags(desc string) []string {
+	var tags []string
+	for _, m := range hashtagPattern.FindAllStringSubmatch(desc, -1) {
+		tags = append(tags, m[1])
+	}
+	return NormalizeTags(tags)
+}
+
+// NormalizeTags lowercases, trims and de-duplicates tags, preserving order.
+// Comma separated values are split so "--tag a,b" works as expected.
+func NormalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, raw := range tags {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+			if t == "" || seen[t] {
+				continue
+			}
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// JoinTags formats tags for the sheet's tags column.
+func JoinTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// SplitTags parses the sheet's tags column.
+func SplitTags(cell string) []string {
+	return NormalizeTags([]string{cell})
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		desc string
+		want []string
+	}{
+		{"fix login #bug #Urgent", []string{"bug", "urgent"}},
+		{"#meeting with #client-a", []string{"meeting", "client-a"}},
+		{"#bug and #BUG again", []string{"bug"}},
+		{"review PR #123", nil},
+		{"email a#b", nil},
+		{"no tags here", nil},
+	}
+	for _, tt := range tests {
+		if got := ParseTags(tt.desc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTags(%q) = %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want []string
+	}{
+		{[]string{"Bug", " #urgent "}, []string{"bug", "urgent"}},
+		{[]string{"a,b", "B", "c"}, []string{"a", "b", "c"}},
+		{[]string{"", " , "}, nil},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTags(tt.tags); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NormalizeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EntryTemplate is a recurring entry such as a daily standup, logged by
+// 'template apply' on every weekday listed in On.
+type EntryTemplate struct {
+	Name   string  `json:"name"`
+	Bucket string  `json:"bucket"`
+	Task   string  `json:"task"`
+	Hours  float64 `json:"hours"`
+	On     string  `json:"on"`
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseWeekdays reads a day spec like "mon-fri", "mon,wed,fri", "daily" or
+// "weekdays" into a set of weekdays.
+func ParseWeekdays(spec string) (map[time.Weekday]bool, error) {
+	days := map[time.Weekday]bool{}
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case "daily", "everyday":
+		spec = "sun-sat"
+	case "weekdays", "":
+		spec = "mon-fri"
+	case "weekends":
+		spec = "sat,sun"
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := parseWeekday(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = parseWeekday(to); err != nil {
+				return nil, err
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) >= 3 {
+		for i, n := range weekdayNames {
+			if strings.HasPrefix(name, n) {
+				return time.Weekday(i), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday '%s'", name)
+}
+
+// DueOn reports whether the template should be logged on day.
+func (t EntryTemplate) DueOn(day time.Time) bool {
+	days, err := ParseWeekdays(t.On)
+	return err == nil && days[day.Weekday()]
+}
+
+// SetTemplate adds or replaces the template with t's name.
+func (c *Config) SetTemplate(t EntryTemplate) {
+	for i, existing := range c.Templates {
+		if existing.Name == t.Name {
+			c.Templates[i] = t
+			return
+		}
+	}
+	c.Templates = append(c.Templates, t)
+}
+
+// RemoveTemplate deletes the named template and reports whether it existed.
+func (c *Config) RemoveTemplate(name string) bool {
+	for i, t := range c.Templates {
+		if t.Name == name {
+			c.Templates = append(c.Templates[:i], c.Templates[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
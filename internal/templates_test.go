@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWeekdays(t *testing.T) {
+	const (
+		sun = time.Sunday
+		mon = time.Monday
+		tue = time.Tuesday
+		wed = time.Wednesday
+		thu = time.Thursday
+		fri = time.Friday
+		sat = time.Saturday
+	)
+	tests := []struct {
+		spec    string
+		want    []time.Weekday
+		wantErr bool
+	}{
+		{spec: "", want: []time.Weekday{mon, tue, wed, thu, fri}},
+		{spec: "weekdays", want: []time.Weekday{mon, tue, wed, thu, fri}},
+		{spec: "mon-fri", want: []time.Weekday{mon, tue, wed, thu, fri}},
+		{spec: "Daily", want: []time.Weekday{sun, mon, tue, wed, thu, fri, sat}},
+		{spec: "weekends", want: []time.Weekday{sat, sun}},
+		{spec: "mon,wed,fri", want: []time.Weekday{mon, wed, fri}},
+		{spec: " Monday , Thursday ", want: []time.Weekday{mon, thu}},
+		{spec: "fri-mon", want: []time.Weekday{fri, sat, sun, mon}},
+		{spec: "tue-tue", want: []time.Weekday{tue}},
+		{spec: "mo", wantErr: true},
+		{spec: "mon-xyz", wantErr: true},
+		{spec: "mon,,fri", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekdays(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseWeekdays(%q) = %v, want an error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseWeekdays(%q): %v", tt.spec, err)
+			continue
+		}
+		want := map[time.Weekday]bool{}
+		for _, d := range tt.want {
+			want[d] = true
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseWeekdays(%q) = %v, want %v", tt.spec, got, want)
+		}
+	}
+}
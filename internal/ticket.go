@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	jiraTicketPattern   = regexp.MustCompile(`\b([A-Z][A-Z0-9]+)-\d+\b`)
+	githubTicketPattern = regexp.MustCompile(`(?:^|\s)#(\d+)\b`)
+	issueNumberPattern  = regexp.MustCompile(`^\d+$`)
+	lettersPattern      = regexp.MustCompile(`^[A-Z]+$`)
+)
+
+// nonTicketKeys are prefixes of version, standard and algorithm names that
+// look like Jira keys, such as UTF-8, SHA-256 or ISO-8601.
+var nonTicketKeys = map[string]bool{
+	"AES": true, "CVE": true, "GMT": true, "HTTP": true, "IEEE": true,
+	"ISO": true, "MD": true, "PEP": true, "RFC": true, "RSA": true,
+	"SHA": true, "SSL": true, "TLS": true, "UTC": true, "UTF": true,
+}
+
+// DetectTicket finds an issue reference in a task description, either a
+// Jira-style key (ABC-123) or a GitHub-style number (#123). With prefixes
+// only keys of those projects count; without, keys must be letters only
+// and not a known non-ticket name like UTF-8.
+func DetectTicket(desc string, prefixes []string) string {
+	for _, m := range jiraTicketPattern.FindAllStringSubmatch(desc, -1) {
+		if isTicketKey(m[1], prefixes) {
+			return m[0]
+		}
+	}
+	if m := githubTicketPattern.FindStringSubmatch(desc); m != nil {
+		return "#" + m[1]
+	}
+	return ""
+}
+
+func isTicketKey(key string, prefixes []string) bool {
+	if len(prefixes) > 0 {
+		for _, p := range prefixes {
+			if strings.EqualFold(p, key) {
+				return true
+			}
+		}
+		return false
+	}
+	return lettersPattern.MatchString(key) && !nonTicketKeys[key]
+}
+
+// NormalizeTicket uppercases Jira keys and turns bare issue numbers into
+// "#123" references, the form DetectTicket records.
+func NormalizeTicket(ticket string) string {
+	ticket = strings.ToUpper(strings.TrimSpace(ticket))
+	if issueNumberPattern.MatchString(ticket) {
+		return "#" + ticket
+	}
+	return ticket
+}
@@ -0,0 +1,55 @@
+package internal
+
+import "testing"
+
+func TestDetectTicket(t *testing.T) {
+	tests := []struct {
+		desc     string
+		prefixes []string
+		want     string
+	}{
+		{desc: "PROJ-12 add login", want: "PROJ-12"},
+		{desc: "review #45", want: "#45"},
+		{desc: "#45 first", want: "#45"},
+		{desc: "PROJ-1 and #2", want: "PROJ-1"},
+		{desc: "proj-12 lowercase", want: ""},
+		{desc: "issue#45", want: ""},
+		{desc: "#tag only", want: ""},
+		{desc: "", want: ""},
+
+		// Without prefixes, only letter keys that are not standards.
+		{desc: "fix crash in AB2-7", want: ""},
+		{desc: "convert to UTF-8", want: ""},
+		{desc: "hash with SHA-256", want: ""},
+		{desc: "dates as ISO-8601", want: ""},
+		{desc: "UTF-8 cleanup for OPS-4", want: "OPS-4"},
+
+		// With prefixes, only those projects.
+		{desc: "fix crash in AB2-7", prefixes: []string{"AB2"}, want: "AB2-7"},
+		{desc: "PROJ-12 and OPS-4", prefixes: []string{"ops"}, want: "OPS-4"},
+		{desc: "PROJ-12 add login", prefixes: []string{"OPS"}, want: ""},
+		{desc: "UTF-8 and #9", prefixes: []string{"OPS"}, want: "#9"},
+	}
+	for _, tt := range tests {
+		if got := DetectTicket(tt.desc, tt.prefixes); got != tt.want {
+			t.Errorf("DetectTicket(%q, %q) = %q, want %q", tt.desc, tt.prefixes, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTicket(t *testing.T) {
+	tests := []struct {
+		ticket string
+		want   string
+	}{
+		{"proj-12", "PROJ-12"},
+		{" #45 ", "#45"},
+		{"45", "#45"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTicket(tt.ticket); got != tt.want {
+			t.Errorf("NormalizeTicket(%q) = %q, want %q", tt.ticket, got, tt.want)
+		}
+	}
+}